@@ -0,0 +1,70 @@
+package clock
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// FullJitterBackoff implements exponential backoff with "full jitter" as described in the AWS architecture blog:
+// the n-th sleep is a random duration between 0 and min(cap, base * 2^n). Sleeping is done with the Clock, so the
+// backoff can be tested with a Mock and a seeded random number generator.
+type FullJitterBackoff struct {
+	mu      sync.Mutex
+	clock   Clock
+	base    time.Duration
+	cap     time.Duration
+	rng     *rand.Rand
+	attempt int
+}
+
+// NewFullJitterBackoff returns a FullJitterBackoff. If rng is nil, a generator seeded with the current time is used.
+func NewFullJitterBackoff(c Clock, base, cap time.Duration, rng *rand.Rand) *FullJitterBackoff {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &FullJitterBackoff{clock: c, base: base, cap: cap, rng: rng}
+}
+
+// Next returns the duration of the next sleep and advances to the next attempt.
+func (b *FullJitterBackoff) Next() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	limit := b.cap
+	// base * 2^attempt, without overflowing
+	if b.attempt < 63 && b.base <= b.cap>>uint(b.attempt) {
+		limit = b.base << uint(b.attempt)
+	}
+	b.attempt++
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(b.rng.Int63n(int64(limit)))
+}
+
+// Sleep sleeps for the duration returned by Next. It returns early with the error of ctx if ctx is done first.
+func (b *FullJitterBackoff) Sleep(ctx context.Context) error {
+	t := b.clock.NewTimer(b.Next())
+	defer t.Stop()
+	select {
+	case <-t.Chan():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Attempt returns the number of sleeps calculated since the backoff was created or reset.
+func (b *FullJitterBackoff) Attempt() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.attempt
+}
+
+// Reset starts over with the first attempt.
+func (b *FullJitterBackoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempt = 0
+}
@@ -0,0 +1,79 @@
+package clock
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullJitterBackoff_Bounds(t *testing.T) {
+	b := NewFullJitterBackoff(NewMock(), time.Second, time.Minute, rand.New(rand.NewSource(1)))
+	limits := []time.Duration{1, 2, 4, 8, 16, 32, 60, 60, 60}
+	for i := 0; i < 100; i++ {
+		b.Reset()
+		for _, limit := range limits {
+			d := b.Next()
+			assert.True(t, d >= 0)
+			assert.True(t, d < limit*time.Second)
+		}
+	}
+	assert.Equal(t, len(limits), b.Attempt())
+
+	// Huge attempt counts don't overflow
+	for i := 0; i < 100; i++ {
+		d := b.Next()
+		assert.True(t, d >= 0 && d < time.Minute)
+	}
+}
+
+func TestFullJitterBackoff_Reproducible(t *testing.T) {
+	a := NewFullJitterBackoff(NewMock(), time.Second, time.Minute, rand.New(rand.NewSource(42)))
+	b := NewFullJitterBackoff(NewMock(), time.Second, time.Minute, rand.New(rand.NewSource(42)))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, a.Next(), b.Next())
+	}
+}
+
+func TestFullJitterBackoff_Sleep(t *testing.T) {
+	expected := NewFullJitterBackoff(nil, time.Second, time.Minute, rand.New(rand.NewSource(7)))
+	clock := NewMock()
+	b := NewFullJitterBackoff(clock, time.Second, time.Minute, rand.New(rand.NewSource(7)))
+
+	for i := 0; i < 5; i++ {
+		d := expected.Next()
+		done := make(chan error, 1)
+		go func() { done <- b.Sleep(context.Background()) }()
+
+		if d > 0 {
+			// Wait until the goroutine has created its timer, otherwise the Forward would miss it
+			for clock.Len() == 0 {
+				sched()
+			}
+			clock.Forward(d - 1)
+			assert.Empty(t, done)
+		}
+		clock.Forward(1)
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Sleep did not return")
+		}
+	}
+}
+
+func TestFullJitterBackoff_SleepCancel(t *testing.T) {
+	clock := NewMock()
+	b := NewFullJitterBackoff(clock, time.Hour, time.Hour, rand.New(rand.NewSource(1)))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.Sleep(ctx) }()
+	sched()
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Zero(t, clock.Len())
+}
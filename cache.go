@@ -0,0 +1,116 @@
+package clock
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RefreshCache is a cache whose entries expire after a fixed TTL. Instead of letting entries go stale, it reloads
+// every entry shortly before it expires. The reloads are scheduled with Clock.AfterFunc, so they can be driven by a
+// Mock during tests.
+type RefreshCache[K comparable, V any] struct {
+	mu            sync.Mutex
+	clock         Clock
+	ttl           time.Duration
+	refreshBefore time.Duration
+	load          func(K) V
+	entries       map[K]*refreshEntry[V]
+}
+
+// refreshEntry is a single value stored in a RefreshCache.
+type refreshEntry[V any] struct {
+	value   V
+	expires time.Time
+	timer   Timer
+}
+
+// NewRefreshCache returns a RefreshCache that keeps its entries for ttl. Every entry is reloaded with load when
+// refreshBefore is left until it expires. NewRefreshCache panics if ttl is not positive or refreshBefore is not
+// smaller than ttl, since entries would then be reloaded over and over at the same instant.
+func NewRefreshCache[K comparable, V any](
+	c Clock, ttl, refreshBefore time.Duration, load func(K) V,
+) *RefreshCache[K, V] {
+	if ttl <= 0 {
+		panic(fmt.Sprintf("clock: non-positive ttl %v passed to NewRefreshCache", ttl))
+	}
+	if refreshBefore >= ttl {
+		panic(fmt.Sprintf("clock: refreshBefore %v passed to NewRefreshCache is not smaller than ttl %v",
+			refreshBefore, ttl))
+	}
+	return &RefreshCache[K, V]{
+		clock:         c,
+		ttl:           ttl,
+		refreshBefore: refreshBefore,
+		load:          load,
+		entries:       make(map[K]*refreshEntry[V]),
+	}
+}
+
+// Get returns the value stored for key. If there is no value or the value has expired, it is loaded and a refresh
+// is scheduled.
+func (r *RefreshCache[K, V]) Get(key K) V {
+	r.mu.Lock()
+	e, ok := r.entries[key]
+	if ok && r.clock.Now().Before(e.expires) {
+		r.mu.Unlock()
+		return e.value
+	}
+	r.mu.Unlock()
+
+	return r.store(key, r.load(key))
+}
+
+// Delete removes key from the cache and stops its refresh.
+func (r *RefreshCache[K, V]) Delete(key K) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.entries[key]; ok {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+		delete(r.entries, key)
+	}
+}
+
+// Close removes all entries from the cache and stops their refreshes.
+func (r *RefreshCache[K, V]) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, e := range r.entries {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+		delete(r.entries, key)
+	}
+}
+
+// refresh reloads the value for key, unless it has been deleted in the meantime.
+func (r *RefreshCache[K, V]) refresh(key K) {
+	r.mu.Lock()
+	e, ok := r.entries[key]
+	if ok {
+		// The timer has already fired, there is nothing left to stop
+		e.timer = nil
+	}
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	r.store(key, r.load(key))
+}
+
+// store saves value for key and schedules the next refresh.
+func (r *RefreshCache[K, V]) store(key K, value V) V {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.entries[key]; ok && e.timer != nil {
+		e.timer.Stop()
+	}
+	r.entries[key] = &refreshEntry[V]{
+		value:   value,
+		expires: r.clock.Now().Add(r.ttl),
+		timer:   r.clock.AfterFunc(r.ttl-r.refreshBefore, func() { r.refresh(key) }),
+	}
+	return value
+}
@@ -0,0 +1,59 @@
+package clock
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshCache_Refresh(t *testing.T) {
+	clock := NewMock()
+	var loads int32
+	load := func(key string) string {
+		n := atomic.AddInt32(&loads, 1)
+		return fmt.Sprintf("%s%d", key, n)
+	}
+	cache := NewRefreshCache(clock, time.Minute, 10*time.Second, load)
+	defer cache.Close()
+
+	assert.Equal(t, "a1", cache.Get("a"))
+	assert.Equal(t, "a1", cache.Get("a"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+
+	clock.Forward(49 * time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+	clock.Forward(time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&loads))
+	assert.Equal(t, "a2", cache.Get("a"))
+
+	// The refreshed entry is valid for another full TTL and is refreshed again before it expires
+	clock.Forward(50 * time.Second)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&loads))
+	assert.Equal(t, "a3", cache.Get("a"))
+}
+
+func TestRefreshCache_Delete(t *testing.T) {
+	clock := NewMock()
+	var loads int32
+	load := func(key string) int32 { return atomic.AddInt32(&loads, 1) }
+	cache := NewRefreshCache(clock, time.Minute, 10*time.Second, load)
+
+	cache.Get("a")
+	cache.Delete("a")
+	clock.Forward(time.Hour)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+	assert.Zero(t, clock.Len())
+}
+
+func TestRefreshCache_InvalidDurations(t *testing.T) {
+	load := func(key string) string { return key }
+	assert.PanicsWithValue(t, "clock: non-positive ttl 0s passed to NewRefreshCache", func() {
+		NewRefreshCache(NewMock(), 0, -time.Second, load)
+	})
+	assert.PanicsWithValue(t, "clock: refreshBefore 1m0s passed to NewRefreshCache is not smaller than ttl 1m0s",
+		func() { NewRefreshCache(NewMock(), time.Minute, time.Minute, load) })
+	assert.Panics(t, func() { NewRefreshCache(NewMock(), time.Minute, time.Hour, load) })
+}
@@ -0,0 +1,99 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Chronometer measures elapsed time with support for pausing and recording laps. Time that passes while the
+// Chronometer is paused is not counted. All readings are based on Clock.Now, so a Mock can be used to control it.
+type Chronometer struct {
+	mu      sync.Mutex
+	clock   Clock
+	running bool
+	// since is the time the Chronometer was last started or resumed
+	since time.Time
+	// elapsed is the time accumulated before the Chronometer was last started or resumed
+	elapsed time.Duration
+	// lapMark is the elapsed time at which the current lap started
+	lapMark time.Duration
+	laps    []time.Duration
+}
+
+// NewChronometer returns a stopped Chronometer based on c.
+func NewChronometer(c Clock) *Chronometer {
+	return &Chronometer{clock: c}
+}
+
+// Start starts the Chronometer. It has no effect if the Chronometer is already running.
+// A paused Chronometer continues where it was paused, just like with Resume.
+func (c *Chronometer) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.running {
+		return
+	}
+	c.running = true
+	c.since = c.clock.Now()
+}
+
+// Pause pauses the Chronometer. The time until it is resumed is not counted.
+func (c *Chronometer) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.running {
+		return
+	}
+	c.elapsed += c.clock.Since(c.since)
+	c.running = false
+}
+
+// Resume continues a paused Chronometer.
+func (c *Chronometer) Resume() {
+	c.Start()
+}
+
+// Elapsed returns the total time the Chronometer has been running.
+func (c *Chronometer) Elapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total()
+}
+
+// Lap records and returns the running time since the previous lap, or since the start for the first lap.
+func (c *Chronometer) Lap() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	total := c.total()
+	lap := total - c.lapMark
+	c.lapMark = total
+	c.laps = append(c.laps, lap)
+	return lap
+}
+
+// Laps returns all recorded laps.
+func (c *Chronometer) Laps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	laps := make([]time.Duration, len(c.laps))
+	copy(laps, c.laps)
+	return laps
+}
+
+// Reset stops the Chronometer and clears the elapsed time and all laps.
+func (c *Chronometer) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running = false
+	c.elapsed = 0
+	c.lapMark = 0
+	c.laps = nil
+}
+
+// total returns the total running time. The caller must hold c.mu.
+func (c *Chronometer) total() time.Duration {
+	if !c.running {
+		return c.elapsed
+	}
+	return c.elapsed + c.clock.Since(c.since)
+}
@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChronometer_PauseResume(t *testing.T) {
+	clock := NewMock()
+	c := NewChronometer(clock)
+
+	// Not started yet
+	clock.Forward(time.Minute)
+	assert.Zero(t, c.Elapsed())
+
+	c.Start()
+	clock.Forward(10 * time.Second)
+	assert.Equal(t, 10*time.Second, c.Elapsed())
+
+	c.Pause()
+	clock.Forward(time.Hour)
+	assert.Equal(t, 10*time.Second, c.Elapsed())
+
+	c.Resume()
+	clock.Forward(5 * time.Second)
+	assert.Equal(t, 15*time.Second, c.Elapsed())
+
+	c.Reset()
+	assert.Zero(t, c.Elapsed())
+	clock.Forward(time.Minute)
+	assert.Zero(t, c.Elapsed())
+}
+
+func TestChronometer_Laps(t *testing.T) {
+	clock := NewMock()
+	c := NewChronometer(clock)
+	c.Start()
+
+	clock.Forward(3 * time.Second)
+	assert.Equal(t, 3*time.Second, c.Lap())
+
+	clock.Forward(2 * time.Second)
+	c.Pause()
+	clock.Forward(time.Minute)
+	c.Resume()
+	clock.Forward(time.Second)
+	assert.Equal(t, 3*time.Second, c.Lap())
+
+	assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second}, c.Laps())
+	assert.Equal(t, 6*time.Second, c.Elapsed())
+
+	c.Reset()
+	assert.Empty(t, c.Laps())
+}
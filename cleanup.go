@@ -0,0 +1,39 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// cleanupTimer is a Timer whose function also runs when it is stopped.
+type cleanupTimer struct {
+	Timer
+	once sync.Once
+	fn   func()
+}
+
+// NewCleanupTimer returns a Timer that calls fn after the duration d has elapsed on c, or when the Timer is stopped
+// before, whichever happens first. This can be used for cleanup logic that has to run either at a deadline or on
+// cancellation. fn runs at most once, even if the Timer is reset afterwards.
+//
+// Unlike the Stop method of time.Timer, Stop calls fn synchronously if the Timer has not fired yet. Stop still
+// returns true in that case.
+func NewCleanupTimer(c Clock, d time.Duration, fn func()) Timer {
+	t := &cleanupTimer{fn: fn}
+	t.Timer = c.AfterFunc(d, t.run)
+	return t
+}
+
+// Stop prevents the Timer from firing and calls its function if it hasn't run yet.
+func (t *cleanupTimer) Stop() bool {
+	stopped := t.Timer.Stop()
+	if stopped {
+		t.run()
+	}
+	return stopped
+}
+
+// run calls the function of the Timer unless it has already run.
+func (t *cleanupTimer) run() {
+	t.once.Do(t.fn)
+}
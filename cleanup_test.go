@@ -0,0 +1,45 @@
+package clock
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanupTimer_Fire(t *testing.T) {
+	clock := NewMock()
+	var runs int32
+	timer := NewCleanupTimer(clock, time.Minute, func() { atomic.AddInt32(&runs, 1) })
+	clock.Forward(time.Minute)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	assert.False(t, timer.Stop())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+}
+
+func TestCleanupTimer_Stop(t *testing.T) {
+	clock := NewMock()
+	var runs int32
+	timer := NewCleanupTimer(clock, time.Minute, func() { atomic.AddInt32(&runs, 1) })
+	clock.Forward(30 * time.Second)
+	assert.True(t, timer.Stop())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	assert.False(t, timer.Stop())
+
+	// The function doesn't run again after a reset
+	timer.Reset(time.Second)
+	clock.Forward(time.Hour)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+}
+
+func TestCleanupTimer_Real(t *testing.T) {
+	done := make(chan struct{})
+	timer := NewCleanupTimer(New(), time.Hour, func() { close(done) })
+	assert.True(t, timer.Stop())
+	select {
+	case <-done:
+	default:
+		t.Fatal("cleanup didn't run on Stop")
+	}
+}
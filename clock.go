@@ -3,8 +3,17 @@
 package clock
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
 	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,15 +26,47 @@ type Executer interface {
 	Execute(time.Time)
 }
 
+// tracking holds the bookkeeping a Mock needs for every Executer it tracks.
+type tracking struct {
+	// seq is the order in which the Executer was added. It orders Executers that are due at the same time.
+	seq uint64
+	// index is the position of the Executer in the list of timers of the Mock, so it can be removed in constant
+	// time. It is only valid as long as the Executer is tracked.
+	index int
+}
+
+// track returns the bookkeeping of an Executer.
+func (t *tracking) track() *tracking { return t }
+
+// tracked is an Executer that can be tracked by a Mock.
+type tracked interface {
+	Executer
+	track() *tracking
+}
+
 // Clock provides an abstraction for often-used time functions
 type Clock interface {
 	// After waits for the duration to elapse and then sends the current time on the returned channel.
 	After(d time.Duration) <-chan time.Time
+	// AfterAny waits for the shortest of the durations to elapse and then sends the current time on the returned
+	// channel. If no duration is given, nothing is ever sent.
+	AfterAny(ds ...time.Duration) <-chan time.Time
+	// AfterAll waits for the longest of the durations to elapse and then sends the current time on the returned
+	// channel. If no duration is given, the time is sent immediately.
+	AfterAll(ds ...time.Duration) <-chan time.Time
 	// AfterFunc waits for the duration to elapse and then executes a function.
 	// A Timer is returned that can be stopped.
 	AfterFunc(d time.Duration, fn func()) Timer
 	// Now returns the current local time.
 	Now() time.Time
+	// NowUnix returns the current time as the number of seconds elapsed since the Unix epoch.
+	NowUnix() int64
+	// NowUnixMilli returns the current time as the number of milliseconds elapsed since the Unix epoch.
+	NowUnixMilli() int64
+	// NowUnixNano returns the current time as the number of nanoseconds elapsed since the Unix epoch.
+	NowUnixNano() int64
+	// NowString returns the current time formatted according to layout, like Now().Format(layout).
+	NowString(layout string) string
 	// Since returns the time elapsed since t.
 	Since(time.Time) time.Duration
 	// Until returns the duration until t.
@@ -33,12 +74,52 @@ type Clock interface {
 	// Sleep pauses the current goroutine for at least the duration d.
 	// A negative or zero duration causes Sleep to return immediately.
 	Sleep(time.Duration)
+	// SleepContext pauses the current goroutine for at least the duration d or until ctx is done, whichever happens
+	// first. It returns ctx.Err() if ctx is done first and nil otherwise. If both happen at once, the elapsed
+	// duration wins and SleepContext returns nil.
+	SleepContext(ctx context.Context, d time.Duration) error
+	// WaitTimeout waits for wg until the duration d has elapsed. It returns true if wg completed before. If wg never
+	// completes, the goroutine waiting for it is leaked.
+	WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool
+	// Every calls fn every d until the returned cancel function is called. A call of fn that is already in progress
+	// is not interrupted by cancel. Every panics if d <= 0.
+	Every(d time.Duration, fn func()) (cancel func())
 	// NewTicker returns a new Ticker containing a channel that will send the
 	// time with a period specified by the duration argument.
 	NewTicker(d time.Duration) Ticker
+	// TryNewTicker behaves like NewTicker, but returns ErrNonPositiveInterval instead of panicking if d <= 0.
+	TryNewTicker(d time.Duration) (Ticker, error)
+	// NewTickerChan behaves like NewTicker, but returns the channel of the Ticker as well. This way, the channel can
+	// be used in a select statement directly while the Ticker can still be stopped.
+	NewTickerChan(d time.Duration) (Ticker, <-chan time.Time)
+	// NewTickerContext behaves like NewTicker, but the Ticker is stopped when ctx is done. If ctx is already done,
+	// the returned Ticker is stopped and never ticks.
+	NewTickerContext(ctx context.Context, d time.Duration) Ticker
+	// NewTickerAt returns a new Ticker that sends the time on its channel at start and then with a period
+	// specified by the duration argument. If start has already passed, the first tick is sent immediately.
+	NewTickerAt(start time.Time, d time.Duration) Ticker
+	// NewTickerImmediate behaves like NewTicker, but the Ticker also ticks right away, before the first period has
+	// elapsed.
+	NewTickerImmediate(d time.Duration) Ticker
 	// NewTimer creates a new Timer that will send
 	// the current time on its channel after at least duration d.
 	NewTimer(d time.Duration) Timer
+	// NewTimerReset behaves like NewTimer, but also returns a function that resets the Timer to expire after a new
+	// duration. The function stops the Timer and drains its channel before resetting it, so no stale value is
+	// received afterwards. It must not be called concurrently with receives from the channel of the Timer.
+	NewTimerReset(d time.Duration) (Timer, func(time.Duration))
+	// NewTimerAt creates a new Timer that will send the current time on its channel at t.
+	// If t has already passed, the Timer fires immediately.
+	NewTimerAt(t time.Time) Timer
+	// NewTimerCapped behaves like NewTimer, but the Timer fires no later than notAfter, i.e. after the shorter of d
+	// and the duration until notAfter. This bounds e.g. retries by an overall deadline.
+	NewTimerCapped(d time.Duration, notAfter time.Time) Timer
+	// SleepUntilNext pauses the current goroutine until the next multiple of period since the Unix epoch,
+	// e.g. the top of the next minute. A negative or zero period causes SleepUntilNext to return immediately.
+	SleepUntilNext(period time.Duration)
+	// Jitter returns a random duration between base and base+factor*base. If factor is not positive, base is
+	// returned.
+	Jitter(base time.Duration, factor float64) time.Duration
 }
 
 // New returns a Clock implementation based on the time package and is good for usage in deployed applications.
@@ -46,28 +127,145 @@ func New() Clock {
 	return &clock{}
 }
 
+// NewOffset returns a Clock based on the time package whose reported time is shifted by offset. Only the absolute
+// time reading is affected: Now, Since and Until are computed against the shifted time, while durations passed to
+// After, AfterFunc, Sleep, NewTicker and NewTimer still elapse in real time. Multiple offset clocks can be used in
+// the same process to simulate clock skew.
+func NewOffset(offset time.Duration) Clock {
+	return NewRealWithNow(func() time.Time { return time.Now().Add(offset) })
+}
+
+// NewRealWithNow returns a Clock based on the time package whose time reading is provided by now: Now, Since and
+// Until use now, while After, AfterFunc, Sleep, NewTicker and NewTimer use real timers. This can be used when only
+// the time reading needs to be controlled, e.g. in property-based tests.
+//
+// Nothing keeps the reported time consistent with the timers: a Timer fires after its duration has elapsed in real
+// time, no matter what now reports at that point. The delays of NewTimerAt, NewTickerAt and SleepUntilNext are
+// computed from the reported time when they are called.
+func NewRealWithNow(now func() time.Time) Clock {
+	return &nowClock{now: now}
+}
+
 // NewMock returns a Mock which implements Clock. It can be used to mock the current time in tests.
-// When a new Mock is created, it starts with Unix timestamp 0.
-func NewMock() *Mock {
+// When a new Mock is created, it starts with Unix timestamp 0. Its behaviour can be customized with Options.
+func NewMock(opts ...Option) *Mock {
+	return NewMockAt(time.Unix(0, 0), opts...)
+}
+
+// NewMockAt behaves like NewMock, but the Mock starts at the time start instead of Unix timestamp 0.
+func NewMockAt(start time.Time, opts ...Option) *Mock {
 	m := &Mock{}
 	m.changed = make(chan time.Time)
-	m.now = time.Unix(0, 0)
+	m.start = start
+	m.setNow(start)
+	m.created = time.Now()
+	m.jitter = rand.New(rand.NewSource(1))
+	for _, opt := range opts {
+		opt(m)
+	}
 	return m
 }
 
+// Option configures a Mock.
+type Option func(*Mock)
+
+// WithDroppedSendHandler registers a handler that is called whenever a Timer fires while the buffer of its channel
+// is still full. Like the time package, the Mock drops the value in that case. The handler helps to catch tests that
+// forgot to read a channel.
+func WithDroppedSendHandler(fn func(Timer)) Option {
+	return func(m *Mock) { m.droppedSend = fn }
+}
+
+// WithSendTimeout makes a Timer whose channel is full wait for up to d of real time for a slow reader before its
+// value is dropped. By default, the value is dropped right away. Dropped values are counted in Stats and reported to
+// the handler registered with WithDroppedSendHandler. The wait blocks the call that fires the Timer, e.g. Forward,
+// but no lock of the Mock is held meanwhile. Tickers are not affected, they never wait.
+func WithSendTimeout(d time.Duration) Option {
+	return func(m *Mock) { m.sendTimeout = d }
+}
+
+// WithMaxForward limits the duration a single call to Forward may move the internal time. Forward panics and
+// TryForward returns an error if the limit is exceeded. This catches accidentally huge advances in test setups.
+// By default, there is no limit.
+func WithMaxForward(limit time.Duration) Option {
+	return func(m *Mock) { m.maxForward = limit }
+}
+
+// WithDriftRate makes the Mock drift relative to the durations passed to Forward: forwarding by d moves the internal
+// time by d*(1+ppm/1e6). A positive rate makes the Mock run fast, so Timers fire early in terms of requested
+// durations, a negative rate makes it run slow. This can be used to test code that detects and corrects clock
+// drift. Only Forward and TryForward are affected, Set and ForwardTo still move the internal time to the exact time.
+func WithDriftRate(ppm float64) Option {
+	return func(m *Mock) { m.driftRate = ppm }
+}
+
+// WithYield replaces the function the Mock uses to give other goroutines the chance to run, e.g. after Forward has
+// fired Timers and Tickers or a Ticker has ticked. By default, the Mock sleeps for a millisecond. A custom function
+// can implement a barrier that guarantees readers have run, which makes tests deterministic.
+func WithYield(fn func()) Option {
+	return func(m *Mock) { m.yield = fn }
+}
+
+// WithTickerOrder configures the order in which Tickers fire when they are due at the same instant during Forward or
+// Set. By default, they fire in the order they have been created. This matters for consumers that read from several
+// Tickers in turn.
+func WithTickerOrder(p TickerOrderPolicy) Option {
+	return func(m *Mock) { m.tickerOrder = p }
+}
+
+// WithIdleIncludingTickers makes the callback registered with OnIdle wait until all Tickers have been stopped, too.
+// By default, only pending Timers count as scheduled work.
+func WithIdleIncludingTickers() Option {
+	return func(m *Mock) { m.idleTickers = true }
+}
+
+// WithJitterSeed seeds the source that Jitter draws from. By default, the source is seeded with 1.
+func WithJitterSeed(seed int64) Option {
+	return func(m *Mock) { m.jitter = rand.New(rand.NewSource(seed)) }
+}
+
+// WithTimeSource configures an external time source that drives the Mock whenever Sync is called. This can be used to
+// replay recorded timestamps, e.g. from a trace file.
+func WithTimeSource(fn func() time.Time) Option {
+	return func(m *Mock) { m.source = fn }
+}
+
 // clock is a wrapper type that implements the standard time functions.
 type clock struct{}
 
 // After waits for the duration to elapse and then sends the current time on the returned channel.
 func (c *clock) After(d time.Duration) <-chan time.Time { return time.After(d) }
 
+// AfterAny waits for the shortest of the durations to elapse and then sends the current time on the returned channel.
+func (c *clock) AfterAny(ds ...time.Duration) <-chan time.Time {
+	if len(ds) == 0 {
+		return make(chan time.Time)
+	}
+	return time.After(shortest(ds))
+}
+
+// AfterAll waits for the longest of the durations to elapse and then sends the current time on the returned channel.
+func (c *clock) AfterAll(ds ...time.Duration) <-chan time.Time { return time.After(longest(ds)) }
+
 // AfterFunc waits for the duration to elapse and then executes a function.
 // A Timer is returned that can be stopped.
-func (c *clock) AfterFunc(d time.Duration, fn func()) Timer { return &realTimer{time.AfterFunc(d, fn)} }
+func (c *clock) AfterFunc(d time.Duration, fn func()) Timer { return newFuncTimer(d, fn) }
 
 // Now returns the current local time.
 func (c *clock) Now() time.Time { return time.Now() }
 
+// NowUnix returns the current time as the number of seconds elapsed since the Unix epoch.
+func (c *clock) NowUnix() int64 { return time.Now().Unix() }
+
+// NowUnixMilli returns the current time as the number of milliseconds elapsed since the Unix epoch.
+func (c *clock) NowUnixMilli() int64 { return time.Now().UnixMilli() }
+
+// NowUnixNano returns the current time as the number of nanoseconds elapsed since the Unix epoch.
+func (c *clock) NowUnixNano() int64 { return time.Now().UnixNano() }
+
+// NowString returns the current time formatted according to layout.
+func (c *clock) NowString(layout string) string { return time.Now().Format(layout) }
+
 // Since returns the time elapsed since t.
 func (c *clock) Since(t time.Time) time.Duration { return time.Since(t) }
 
@@ -78,52 +276,577 @@ func (c *clock) Until(t time.Time) time.Duration { return time.Until(t) }
 // A negative or zero duration causes Sleep to return immediately.
 func (c *clock) Sleep(d time.Duration) { time.Sleep(d) }
 
+// SleepContext pauses the current goroutine for at least the duration d or until ctx is done.
+func (c *clock) SleepContext(ctx context.Context, d time.Duration) error {
+	return sleepContext(ctx, c.NewTimer(d))
+}
+
+// WaitTimeout waits for wg until the duration d has elapsed. It returns true if wg completed before.
+func (c *clock) WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool {
+	return waitTimeout(wg, c.NewTimer(d))
+}
+
 // NewTicker returns a new Ticker containing a channel that will send the
 // time with a period specified by the duration argument.
 func (c *clock) NewTicker(d time.Duration) Ticker { return &realTicker{time.NewTicker(d)} }
 
+// TryNewTicker returns a new Ticker or ErrNonPositiveInterval if d <= 0.
+func (c *clock) TryNewTicker(d time.Duration) (Ticker, error) {
+	if d <= 0 {
+		return nil, ErrNonPositiveInterval
+	}
+	return c.NewTicker(d), nil
+}
+
+// Every calls fn every d in a new goroutine until the returned cancel function is called.
+func (c *clock) Every(d time.Duration, fn func()) func() { return every(c.NewTicker(d), fn) }
+
+// NewTickerContext returns a new Ticker that is stopped when ctx is done.
+func (c *clock) NewTickerContext(ctx context.Context, d time.Duration) Ticker {
+	return newContextTicker(ctx, c.NewTicker(d))
+}
+
+// NewTickerChan returns a new Ticker and its channel.
+func (c *clock) NewTickerChan(d time.Duration) (Ticker, <-chan time.Time) {
+	t := c.NewTicker(d)
+	return t, t.Chan()
+}
+
 // NewTimer creates a new Timer that will send
 // the current time on its channel after at least duration d.
 func (c *clock) NewTimer(d time.Duration) Timer { return &realTimer{time.NewTimer(d)} }
 
+// NewTimerReset creates a new Timer and a function that safely resets it.
+func (c *clock) NewTimerReset(d time.Duration) (Timer, func(time.Duration)) {
+	t := c.NewTimer(d)
+	return t, resetter(t)
+}
+
+// NewTickerAt returns a new Ticker that sends the time on its channel at start and then with a period
+// specified by the duration argument.
+func (c *clock) NewTickerAt(start time.Time, d time.Duration) Ticker {
+	return newDelayedTicker(time.Until(start), d)
+}
+
+// NewTickerImmediate returns a new Ticker whose first tick is already on the channel when it is returned.
+func (c *clock) NewTickerImmediate(d time.Duration) Ticker {
+	t := newDelayedTicker(d, d)
+	t.send(time.Now())
+	return t
+}
+
+// NewTimerAt creates a new Timer that will send the current time on its channel at t.
+func (c *clock) NewTimerAt(t time.Time) Timer { return c.NewTimer(time.Until(t)) }
+
+// NewTimerCapped creates a new Timer that fires after d, but no later than notAfter.
+func (c *clock) NewTimerCapped(d time.Duration, notAfter time.Time) Timer {
+	return c.NewTimer(capped(d, time.Until(notAfter)))
+}
+
+// SleepUntilNext pauses the current goroutine until the next multiple of period since the Unix epoch.
+func (c *clock) SleepUntilNext(period time.Duration) { time.Sleep(untilNext(time.Now(), period)) }
+
+// Jitter returns a random duration between base and base+factor*base, drawn from the global source of math/rand.
+func (c *clock) Jitter(base time.Duration, factor float64) time.Duration {
+	return jitter(base, factor, rand.Float64)
+}
+
+// nowClock is a clock whose reported time is provided by a function.
+type nowClock struct {
+	clock
+	now func() time.Time
+}
+
+// Now returns the reported time.
+func (c *nowClock) Now() time.Time { return c.now() }
+
+// NowUnix returns the reported time as the number of seconds elapsed since the Unix epoch.
+func (c *nowClock) NowUnix() int64 { return c.Now().Unix() }
+
+// NowUnixMilli returns the reported time as the number of milliseconds elapsed since the Unix epoch.
+func (c *nowClock) NowUnixMilli() int64 { return c.Now().UnixMilli() }
+
+// NowUnixNano returns the reported time as the number of nanoseconds elapsed since the Unix epoch.
+func (c *nowClock) NowUnixNano() int64 { return c.Now().UnixNano() }
+
+// NowString returns the reported time formatted according to layout.
+func (c *nowClock) NowString(layout string) string { return c.Now().Format(layout) }
+
+// Since returns the time elapsed since t in comparison to the reported time.
+func (c *nowClock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+// Until returns the duration until t in comparison to the reported time.
+func (c *nowClock) Until(t time.Time) time.Duration { return t.Sub(c.Now()) }
+
+// NewTickerAt returns a new Ticker that sends the time on its channel when the reported time reaches start and then
+// with a period specified by the duration argument. The delay is computed once, at the creation of the Ticker.
+func (c *nowClock) NewTickerAt(start time.Time, d time.Duration) Ticker {
+	return newDelayedTicker(c.Until(start), d)
+}
+
+// NewTimerAt creates a new Timer that will send the current time on its channel when the reported time reaches t.
+// The delay is computed once, at the creation of the Timer.
+func (c *nowClock) NewTimerAt(t time.Time) Timer { return c.NewTimer(c.Until(t)) }
+
+// NewTimerCapped creates a new Timer that fires after d, but no later than the reported time reaches notAfter. The
+// delay is computed once, at the creation of the Timer.
+func (c *nowClock) NewTimerCapped(d time.Duration, notAfter time.Time) Timer {
+	return c.NewTimer(capped(d, c.Until(notAfter)))
+}
+
+// SleepUntilNext pauses the current goroutine until the reported time reaches the next multiple of period since the
+// Unix epoch.
+func (c *nowClock) SleepUntilNext(period time.Duration) { time.Sleep(untilNext(c.Now(), period)) }
+
+// FakeClock is a Clock whose time is controlled by the test. Test helpers can accept a FakeClock instead of a *Mock
+// to work with any fake implementation.
+type FakeClock interface {
+	Clock
+	// Forward moves the time forward by d and fires everything that becomes due.
+	Forward(d time.Duration)
+	// Set sets the time to t and fires everything that becomes due.
+	Set(t time.Time)
+	// RunUntilDone moves the time forward until all Timers have fired.
+	RunUntilDone()
+	// Len returns the number of pending Timers and Tickers.
+	Len() int
+}
+
 // Mock is a type used for mocking the time package during tests.
+//
+// Unlike the times returned by time.Now, the times of a Mock never carry a monotonic clock reading. Monotonic
+// readings are stripped from times passed to the Mock, e.g. by NewMockAt or Set. This way, Since, Until and Sub
+// always compute durations from the internal time, and stripping the monotonic reading with t.Round(0) has no
+// effect. Times of a Mock can also be compared with ==: two calls to Now at the same internal time return equal
+// values, and so do the times sent by Timers and Tickers and the internal time they are due at. Note that this
+// differs from production, where durations between times returned by time.Now are unaffected by changes of the wall
+// clock, whereas moving the internal time with Set is reflected in all durations.
+//
+// Calls that move the internal time, like Forward, ForwardTo, Set and Sync, are serialized: each of them moves the
+// internal time and fires everything that becomes due before the next one starts. This way, concurrent calls fire
+// Timers and Tickers in chronological order. Calls that replace Timers and Tickers wholesale, like Reset, Close,
+// InterruptSleeps and Restore, are serialized the same way. If such a call is made while a callback runs during
+// one of these steps, e.g. from an AfterFunc callback that moves the internal time, it is queued instead: it runs
+// right after the current step, before the outer call returns, and the call from the callback returns immediately.
+// Only calls made by the goroutine running the step are queued. Calls from other goroutines wait until the step is
+// done, so a callback must not wait for another goroutine that moves the internal time.
 type Mock struct {
-	mu      sync.RWMutex
-	now     time.Time
+	mu sync.RWMutex
+	// advanceMu serializes moving the internal time together with firing the Timers and Tickers that become due, and
+	// replacing all Timers and Tickers. It is taken through serialize.
+	advanceMu sync.Mutex
+	// queueMu guards stepping, stepper and queued
+	queueMu sync.Mutex
+	// stepping is true while a serialized step runs
+	stepping bool
+	// stepper is the ID of the goroutine that runs the serialized step
+	stepper uint64
+	// queued holds the steps requested by callbacks while a step runs
+	queued []func()
+	now    time.Time
+	// start is the internal time at which the Mock was created
+	start time.Time
+	// frozen is true while moving the internal time doesn't fire Timers and Tickers
+	frozen bool
+	// current mirrors now so Now can read it without taking the lock. It is only written together with now.
+	current atomic.Value
 	changed chan time.Time
-	timers  []Executer
+	timers  []tracked
+	// seq is the sequence number of the last added Executer
+	seq uint64
+	// droppedSend is called when a Timer can't deliver its value
+	droppedSend func(Timer)
+	// sendTimeout is the real time a Timer waits for room in its channel before dropping its value
+	sendTimeout time.Duration
+	// tickerOrder determines the order of Tickers that are due at the same instant
+	tickerOrder TickerOrderPolicy
+	// tickerPolicy is the TickerPolicy of all Tickers that don't have their own
+	tickerPolicy TickerPolicy
+	// source is the external time source used by Sync
+	source func() time.Time
+	// traceMu guards the operation trace
+	traceMu sync.Mutex
+	// ops holds the operation trace of every traced Timer. It is nil while the trace is disabled.
+	ops map[*fakeTimer][]opRecord
+	// traced holds the traced Timers in the order they were created
+	traced []*fakeTimer
+	// eventLog is true if the event log is enabled. It is only set on construction, so it can be read without lock.
+	eventLog bool
+	// events holds the event log, it is guarded by traceMu
+	events []loggedEvent
+	// created is the real time at which the Mock was created
+	created time.Time
+	// simulated is the total amount of time the Mock has been advanced
+	simulated time.Duration
+	// driftRate is the drift in parts per million that is applied to Forward
+	driftRate float64
+	// requested is the total duration passed to Forward while drifting
+	requested time.Duration
+	// closed is true once Close has been called
+	closed bool
+	// yield gives other goroutines the chance to run. If nil, sched is used.
+	yield func()
+	// maxForward is the longest duration Forward accepts. 0 means no limit.
+	maxForward time.Duration
+	// jitter is the source of Jitter
+	jitter *rand.Rand
+	// beforeFire is called before an Executer is executed
+	beforeFire func(e Executer, at time.Time)
+	// idle is called when a tick leaves no scheduled work
+	idle func()
+	// idleTickers is true if pending Tickers count as scheduled work for idle
+	idleTickers bool
+	// forwards and sets count the calls to Forward and ForwardTo, and Set
+	forwards, sets int
+	// timerFires and tickerFires count the executions of Timers and Tickers. They are accessed atomically.
+	timerFires, tickerFires int64
+	// droppedSends counts the values of Timers that have been dropped. It is accessed atomically.
+	droppedSends int64
 }
 
-// Len returns the number of internal Timers or Tickers that are being tracked.
-func (m *Mock) Len() int { return len(m.timers) }
+// Len returns the number of Timers and Tickers that are being tracked. Changes of the internal time scheduled with
+// PlanSet and pauses scheduled with InjectPause are not counted.
+func (m *Mock) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pendingLocked()
+}
+
+// pendingLocked returns the number of tracked Timers and Tickers without the internal Executers. The caller must hold
+// the lock.
+func (m *Mock) pendingLocked() int {
+	n := 0
+	for _, t := range m.timers {
+		if !isInternal(t) {
+			n++
+		}
+	}
+	return n
+}
+
+// schedule sorts the tracked Executers of a Mock, including the internal ones, by their next execution. The caller
+// must hold the lock of the Mock.
+type schedule struct {
+	m *Mock
+}
+
+// Len returns the number of all tracked Executers.
+func (s schedule) Len() int { return len(s.m.timers) }
 
 // Swap swaps the elements at i and j in the internal tracker for Timers and Tickers
-func (m *Mock) Swap(i, j int) { m.timers[i], m.timers[j] = m.timers[j], m.timers[i] }
+func (s schedule) Swap(i, j int) {
+	timers := s.m.timers
+	timers[i], timers[j] = timers[j], timers[i]
+	timers[i].track().index = i
+	timers[j].track().index = j
+}
+
+// Less indicates whether Executer at position i should be executed before Executer at position j.
+func (s schedule) Less(i, j int) bool {
+	return s.m.before(s.m.timers[i], s.m.timers[j])
+}
 
-// Less indicates whether Executer at position i should be executed before Executer at position j
-func (m *Mock) Less(i, j int) bool {
-	return m.timers[i].NextExecution().Before(m.timers[j].NextExecution())
+// before indicates whether x should be executed before y. Executers that are due at the same time are executed in the
+// order they were added.
+func (m *Mock) before(x, y tracked) bool {
+	a, b := x.NextExecution(), y.NextExecution()
+	if a.Equal(b) {
+		if m.tickerOrder == PeriodAscending {
+			if px, py := period(x), period(y); px != py {
+				return px < py
+			}
+		}
+		return x.track().seq < y.track().seq
+	}
+	return a.Before(b)
+}
+
+// period returns the period of t if it is a Ticker and 0 otherwise. It is used to order Tickers by their period.
+func period(t tracked) time.Duration {
+	if ticker, ok := t.(*fakeTicker); ok {
+		return ticker.d
+	}
+	return 0
 }
 
 // Forward moves the internal time forward by the amount specified. Any timers or tickers that fire during that time
-// period will be activated
+// period will be activated. Forward panics if d is negative, use Set to move the internal time backwards. It also
+// panics if d exceeds the limit configured with WithMaxForward.
+//
+// Timers and Tickers that are created or reset while Forward fires, e.g. by an AfterFunc callback, fire during the
+// same call if they are due by the new internal time. Note that their due time is computed from the new internal
+// time, since that is what Now returns while Forward fires.
 func (m *Mock) Forward(d time.Duration) {
-	m.mu.Lock()
-	t := m.now.Add(d)
-	m.now = t
-	m.mu.Unlock()
-	m.tick(t)
-	sched()
+	m.ForwardWithBarrier(d, func() {})
+}
+
+// ForwardWithBarrier behaves like Forward, but calls barrier after the internal time has been updated and before any
+// Timer or Ticker fires. barrier can e.g. wake up worker goroutines and wait until they have observed the new Now,
+// which gives deterministic control over the interleaving of workers and firing Timers. barrier is called without
+// holding the lock of the Mock. Calls from barrier that move the internal time are queued, see Mock.
+func (m *Mock) ForwardWithBarrier(d time.Duration, barrier func()) {
+	if err := m.checkForward(d); err != nil {
+		panic(err.Error())
+	}
+	m.serialize(func() {
+		m.mu.Lock()
+		d = m.drift(d)
+		m.setNow(m.now.Add(d))
+		m.simulated += d
+		m.forwards++
+		m.mu.Unlock()
+		barrier()
+		m.tick()
+		m.sched()
+	})
+}
+
+// TryForward behaves like Forward, but returns an error instead of panicking if d is negative or exceeds the limit
+// configured with WithMaxForward. In that case, the internal time is left unchanged.
+func (m *Mock) TryForward(d time.Duration) error {
+	if err := m.checkForward(d); err != nil {
+		return err
+	}
+	m.Forward(d)
+	return nil
+}
+
+// drift returns the duration the internal time actually moves when Forward is called with d, taking the drift rate
+// into account. The drift is computed from the total requested duration, so rounding errors don't accumulate across
+// calls. The caller must hold the write lock.
+func (m *Mock) drift(d time.Duration) time.Duration {
+	if m.driftRate == 0 {
+		return d
+	}
+	drifted := func(d time.Duration) time.Duration {
+		return d + time.Duration(math.Round(float64(d)*m.driftRate/1e6))
+	}
+	before := drifted(m.requested)
+	m.requested += d
+	return drifted(m.requested) - before
+}
+
+// checkForward returns an error if d can't be passed to Forward.
+func (m *Mock) checkForward(d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("clock: negative duration %v passed to Forward", d)
+	}
+	if m.maxForward > 0 && d > m.maxForward {
+		return fmt.Errorf("clock: duration %v passed to Forward exceeds the limit of %v", d, m.maxForward)
+	}
+	return nil
+}
+
+// ForwardTo moves the internal time forward to t, firing all Timers and Tickers that become due, just like
+// Forward(t.Sub(m.Now())). It returns an error and leaves the internal time unchanged if t is before the internal
+// time. If ForwardTo is queued because it is called from a callback, the error only reflects the internal time at
+// the call, and t is skipped silently if the internal time has passed it by the time the call runs.
+func (m *Mock) ForwardTo(t time.Time) error {
+	if now := m.Now(); t.Before(now) {
+		return fmt.Errorf("clock: cannot forward to %v, it is before the current time %v", t, now)
+	}
+	var err error
+	m.serialize(func() {
+		m.mu.Lock()
+		now := m.now
+		if t.Before(now) {
+			m.mu.Unlock()
+			err = fmt.Errorf("clock: cannot forward to %v, it is before the current time %v", t, now)
+			return
+		}
+		m.simulated += t.Sub(now)
+		m.forwards++
+		m.setNow(t)
+		m.mu.Unlock()
+		m.tick()
+		m.sched()
+	})
+	return err
+}
+
+// ForwardCalendar moves the internal time forward by the given number of days, hours and months and fires all Timers
+// and Tickers that become due. Days and months are added with the semantics of time.Time.AddDate in the location of
+// the internal time, which can be chosen with NewMockAt or Set. This way, a day that crosses a daylight saving time
+// transition is 23 or 25 hours long, like on a wall clock. Hours are added as elapsed time afterwards.
+// ForwardCalendar panics if the resulting time is before the internal time.
+func (m *Mock) ForwardCalendar(days, hours, months int) {
+	if now := m.Now(); calendarAdd(now, days, hours, months).Before(now) {
+		panic(fmt.Sprintf("clock: ForwardCalendar would move the time backwards from %v to %v", now,
+			calendarAdd(now, days, hours, months)))
+	}
+	m.serialize(func() {
+		m.mu.Lock()
+		now := m.now
+		t := calendarAdd(now, days, hours, months)
+		m.simulated += t.Sub(now)
+		m.forwards++
+		m.setNow(t)
+		m.mu.Unlock()
+		m.tick()
+		m.sched()
+	})
+}
+
+// calendarAdd adds days and months with the semantics of time.Time.AddDate and then hours as elapsed time to t.
+func calendarAdd(t time.Time, days, hours, months int) time.Time {
+	return t.AddDate(0, months, days).Add(time.Duration(hours) * time.Hour)
+}
+
+// ForwardWithStops prepares moving the internal time forward by d with a pause at each of the stops, so the state
+// can be inspected at intermediate instants. Stops outside of [now, now+d] are ignored. The internal time isn't
+// moved until the returned function is called: every call moves it to the next stop, firing all Timers and Tickers
+// that become due, and returns true. Once all stops have been passed, the next call moves the internal time to
+// now+d and returns false, as do all further calls. The stops are visited in chronological order.
+//
+//	next := m.ForwardWithStops(time.Hour, stop1, stop2)
+//	for next() {
+//		// inspect the state at stop1 and stop2
+//	}
+func (m *Mock) ForwardWithStops(d time.Duration, stops ...time.Time) func() bool {
+	now := m.Now()
+	end := now.Add(d)
+	var pending []time.Time
+	for _, s := range stops {
+		if !s.Before(now) && !s.After(end) {
+			pending = append(pending, s)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Before(pending[j]) })
+	done := false
+	return func() bool {
+		if len(pending) > 0 {
+			// A stop is skipped if the internal time has been moved past it in the meantime
+			_ = m.ForwardTo(pending[0])
+			pending = pending[1:]
+			return true
+		}
+		if !done {
+			done = true
+			_ = m.ForwardTo(end)
+		}
+		return false
+	}
+}
+
+// ForwardUntil moves the internal time forward in increments of step, firing Timers and Tickers after every step,
+// until pred returns true or the internal time has been moved by max in total. pred is evaluated before the first
+// step and after every step. The last step is shortened so the internal time is never moved beyond max. ForwardUntil
+// returns the internal time at which it stopped and whether pred was satisfied.
+// It panics if step is not positive.
+func (m *Mock) ForwardUntil(step time.Duration, pred func() bool, max time.Duration) (time.Time, bool) {
+	if step <= 0 {
+		panic(fmt.Sprintf("clock: non-positive step %v passed to ForwardUntil", step))
+	}
+	for elapsed := time.Duration(0); ; elapsed += step {
+		if pred() {
+			return m.Now(), true
+		}
+		if elapsed >= max {
+			return m.Now(), false
+		}
+		if max-elapsed < step {
+			step = max - elapsed
+		}
+		m.Forward(step)
+	}
 }
 
 // Set sets the internal time to a specific point in time. Any timers or tickers that fire during that time
-// period will be activated
+// period will be activated.
+//
+// Set may move the internal time backwards. In that case, nothing fires and Timers and Tickers keep their next
+// execution time, i.e. they fire once the internal time reaches it again. Use SetAndRebaseTickers to keep the phase
+// of Tickers instead.
 func (m *Mock) Set(t time.Time) {
+	m.serialize(func() {
+		m.mu.Lock()
+		if d := t.Sub(m.now); d > 0 {
+			m.simulated += d
+		}
+		m.sets++
+		m.setNow(t)
+		m.mu.Unlock()
+		m.tick()
+		m.sched()
+	})
+}
+
+// SetAndRebaseTickers behaves like Set, but moves the next execution of every Ticker to the earliest time at or after
+// t that is a whole number of periods away from its current next execution. This way, Tickers keep their phase: after
+// moving the internal time backwards, they tick after less than a period instead of waiting until their former next
+// execution, and after moving it forwards, they skip the elapsed periods instead of catching up. Tickers due exactly
+// at t fire. Timers are not affected.
+func (m *Mock) SetAndRebaseTickers(t time.Time) {
+	m.serialize(func() {
+		m.mu.Lock()
+		if d := t.Sub(m.now); d > 0 {
+			m.simulated += d
+		}
+		m.sets++
+		m.setNow(t)
+		now := m.now
+		for _, e := range m.timers {
+			if ticker, ok := e.(*fakeTicker); ok {
+				ticker.mu.Lock()
+				offset := ticker.next.Sub(now) % ticker.d
+				if offset < 0 {
+					offset += ticker.d
+				}
+				ticker.next = now.Add(offset)
+				ticker.mu.Unlock()
+			}
+		}
+		m.mu.Unlock()
+		m.tick()
+		m.sched()
+	})
+}
+
+// Sync advances the internal time to the time returned by the time source configured with WithTimeSource and fires
+// all Timers and Tickers that become due. If the time source returns a time before the internal time, the internal
+// time is left unchanged. Sync panics if the Mock has no time source.
+func (m *Mock) Sync() {
+	if m.source == nil {
+		panic("clock: Sync called on a Mock without time source")
+	}
+	// Call the source without holding the lock, it might call methods of the Mock
+	t := m.source()
+	m.serialize(func() {
+		m.mu.Lock()
+		if !t.After(m.now) {
+			m.mu.Unlock()
+			return
+		}
+		m.simulated += t.Sub(m.now)
+		m.setNow(t)
+		m.mu.Unlock()
+		m.tick()
+		m.sched()
+	})
+}
+
+// Freeze stops Timers and Tickers from firing when the internal time is moved, e.g. by Forward or Set, until Unfreeze
+// is called. This can be used to stage a big jump of the internal time and inspect the pending Timers before anything
+// fires. Timers created with a non-positive duration still fire immediately, and RunUntilDoneN, FireDueWithin and
+// FireAll still fire explicitly.
+func (m *Mock) Freeze() {
 	m.mu.Lock()
-	m.now = t
-	m.mu.Unlock()
-	m.tick(t)
-	sched()
+	defer m.mu.Unlock()
+	m.frozen = true
+}
+
+// Unfreeze lets Timers and Tickers fire again and fires everything that has become due while the Mock was frozen, in
+// chronological order. Since all of it fires at once, readers get no chance to receive in between: a Ticker delivers
+// only as many ticks as the buffer of its channel holds, like a Ticker with a slow reader, and the remaining ticks are
+// dropped. Timers are not affected, each of them sends a single value.
+func (m *Mock) Unfreeze() {
+	m.serialize(func() {
+		m.mu.Lock()
+		m.frozen = false
+		m.mu.Unlock()
+		m.tick()
+		m.sched()
+	})
 }
 
 // RunUntilDone sets the internal time to the point in time where the latest timer will be fired.
@@ -142,120 +865,1054 @@ func (m *Mock) RunUntilDone() {
 	m.Set(last)
 }
 
+// DurationToNext returns how long it takes from the internal time until the next Timer or Ticker fires, so a test
+// driver can decide whether to move the time forward. Nothing is fired. The second return value is false if no Timer
+// or Ticker is pending.
+func (m *Mock) DurationToNext() (time.Duration, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var next time.Time
+	found := false
+	for _, t := range m.timers {
+		if isInternal(t) {
+			continue
+		}
+		if at := t.NextExecution(); !found || at.Before(next) {
+			next, found = at, true
+		}
+	}
+	if !found {
+		return 0, false
+	}
+	if d := next.Sub(m.now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// RunUntilDoneN behaves like RunUntilDone, but Tickers are included: the internal time is moved from one execution
+// to the next until all Timers have fired and every Ticker has ticked at least n times since the call. Tickers that
+// are due while Timers are still pending keep ticking, so they may tick more than n times.
+func (m *Mock) RunUntilDoneN(n int) {
+	ticks := make(map[*fakeTicker]int)
+	for {
+		m.mu.RLock()
+		var next time.Time
+		found := false
+		for _, t := range m.timers {
+			if ticker, ok := t.(*fakeTicker); ok && ticks[ticker] >= n || isInternal(t) {
+				continue
+			}
+			if at := t.NextExecution(); !found || at.Before(next) {
+				next, found = at, true
+			}
+		}
+		var due []*fakeTicker
+		for _, t := range m.timers {
+			if ticker, ok := t.(*fakeTicker); ok && !ticker.NextExecution().After(next) {
+				due = append(due, ticker)
+			}
+		}
+		m.mu.RUnlock()
+		if !found {
+			return
+		}
+
+		m.serialize(func() {
+			m.mu.Lock()
+			if next.After(m.now) {
+				m.simulated += next.Sub(m.now)
+				m.setNow(next)
+			}
+			m.mu.Unlock()
+			// Fire even while the Mock is frozen, otherwise the loop would never end
+			for m.tickNext(0) {
+			}
+			m.sched()
+		})
+		for _, ticker := range due {
+			ticks[ticker]++
+		}
+	}
+}
+
+// SimulatedVsReal returns the total time the Mock has been advanced by Forward and Set, and the real time that has
+// passed since the Mock was created. Moving the time backwards with Set doesn't reduce the simulated time.
+func (m *Mock) SimulatedVsReal() (simulated time.Duration, real time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.simulated, time.Since(m.created)
+}
+
+// DrainTimer receives a value from the channel of t without blocking. It returns the value and whether there was one.
+func (m *Mock) DrainTimer(t Timer) (time.Time, bool) {
+	return drain(t.Chan())
+}
+
+// DrainTicker receives a value from the channel of t without blocking. It returns the value and whether there was
+// one.
+func (m *Mock) DrainTicker(t Ticker) (time.Time, bool) {
+	return drain(t.Chan())
+}
+
+// drain receives a value from ch without blocking.
+func drain(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case v := <-ch:
+		return v, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// AssertNoFire forwards the internal time by within and reports whether ch is still empty, i.e. nothing has been
+// sent on it during that time. Note that AssertNoFire advances the internal time and fires everything that becomes
+// due, just like Forward. A value that has been sent is drained from ch.
+func (m *Mock) AssertNoFire(ch <-chan time.Time, within time.Duration) bool {
+	m.Forward(within)
+	_, fired := drain(ch)
+	return !fired
+}
+
+// State returns the internal time and the number of pending Timers and Tickers at once, so they are consistent with
+// each other. Reading Now and Len separately can race with a concurrent Forward. State waits until a concurrent
+// Forward, Set or similar call has fired all Timers and Tickers that became due. Called from a callback, it returns
+// the state in the middle of the current step right away.
+func (m *Mock) State() (now time.Time, pending int) {
+	read := func() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		now, pending = m.now, m.pendingLocked()
+	}
+	if !m.serialize(read) {
+		read()
+	}
+	return now, pending
+}
+
+// ElapsedSinceStart returns how far the internal time has moved from the time the Mock started at. Unlike the
+// simulated time reported by SimulatedVsReal, moving the internal time backwards reduces it.
+func (m *Mock) ElapsedSinceStart() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.now.Sub(m.start)
+}
+
+// Reset sets the internal time back to the time the Mock started at and stops all Timers and Tickers, so the Mock
+// can be reused. The channels of the stopped Timers and Tickers are not closed, which means goroutines reading from
+// them block forever. Callers must make sure to stop their own readers before calling Reset.
+func (m *Mock) Reset() {
+	m.serialize(func() {
+		m.mu.Lock()
+		m.setNow(m.start)
+		m.mu.Unlock()
+		m.stopAll()
+	})
+}
+
+// ResetSequence restarts the sequence counter that orders Timers and Tickers which are due at the same time, so
+// sequence numbers are reproducible regardless of what ran on the Mock before. Timers and Tickers that are still
+// pending are renumbered from 1 in their current order, the counter continues after them.
+func (m *Mock) ResetSequence() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pending := make([]tracked, len(m.timers))
+	copy(pending, m.timers)
+	sort.Slice(pending, func(i, j int) bool { return pending[i].track().seq < pending[j].track().seq })
+	m.seq = 0
+	for _, t := range pending {
+		m.seq++
+		t.track().seq = m.seq
+	}
+}
+
+// FireAll fires all pending Timers at once, in the order they are due, without advancing the internal time. Tickers
+// and Timers created while FireAll runs are not fired. Channels receive the time the Timer was due at, like they
+// would when the internal time is forwarded. This can be used to flush deferred work, e.g. at the end of a test.
+func (m *Mock) FireAll() {
+	m.serialize(func() {
+		m.mu.Lock()
+		sort.Sort(schedule{m})
+		var timers []*fakeTimer
+		for _, t := range m.timers {
+			if f, ok := t.(*fakeTimer); ok {
+				timers = append(timers, f)
+			}
+		}
+		m.mu.Unlock()
+
+		for _, f := range timers {
+			f.Execute(f.NextExecution())
+		}
+		m.sched()
+	})
+}
+
+// Close stops all pending Timers and Tickers and closes their channels, so goroutines reading from them can exit.
+// Readers should use a loop like
+//
+//	for range ticker.Chan() {
+//	}
+//
+// or check whether the channel has been closed, since a closed channel returns the zero time on every receive.
+// Channels of Timers and Tickers that have already fired or been stopped are not closed. Stopped Timers can't be
+// reset anymore after Close. Close returns an error if the Mock has already been closed. If Close is queued because it
+// is called from a callback, it returns nil.
+func (m *Mock) Close() error {
+	var err error
+	m.serialize(func() { err = m.close() })
+	return err
+}
+
+// close implements Close, it must only be called through serialize.
+func (m *Mock) close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return errors.New("clock: Mock already closed")
+	}
+	m.closed = true
+	timers := m.timers
+	m.timers = nil
+	m.mu.Unlock()
+
+	for _, t := range timers {
+		switch v := t.(type) {
+		case *fakeTimer:
+			v.mu.Lock()
+			v.stopped = true
+			if v.ch != nil {
+				close(v.ch)
+			}
+			v.mu.Unlock()
+		case *fakeTicker:
+			v.mu.Lock()
+			v.stopped = true
+			if v.ch != nil {
+				close(v.ch)
+			}
+			v.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// stopAll stops all Timers and Tickers and removes everything that is tracked. It returns the number of Tickers
+// that were still running.
+func (m *Mock) stopAll() (tickers int) {
+	m.mu.Lock()
+	timers := m.timers
+	m.timers = nil
+	m.mu.Unlock()
+
+	for _, t := range timers {
+		switch v := t.(type) {
+		case Timer:
+			v.Stop()
+		case Ticker:
+			v.Stop()
+			tickers++
+		}
+	}
+	return tickers
+}
+
+// InterruptSleeps releases all goroutines that are blocked in Sleep or waiting on a channel returned by After,
+// AfterAny or AfterAll, without moving the internal time. The waiters receive the current internal time. This can be
+// used to simulate a shutdown and test that code doesn't hang when it is torn down in the middle of a sleep.
+// Timers created with NewTimer or AfterFunc and calls to SleepContext are not affected, the latter can be interrupted
+// through their context.
+func (m *Mock) InterruptSleeps() {
+	m.serialize(func() {
+		m.mu.RLock()
+		now := m.now
+		timers := make([]tracked, len(m.timers))
+		copy(timers, m.timers)
+		m.mu.RUnlock()
+
+		for _, t := range timers {
+			f, ok := t.(*fakeTimer)
+			if !ok {
+				continue
+			}
+			m.mu.Lock()
+			f.mu.Lock()
+			if f.waiter && !f.stopped {
+				f.stopped = true
+				select {
+				case f.ch <- now:
+				default:
+				}
+				m.removeTimerLocked(f)
+			}
+			f.mu.Unlock()
+			m.mu.Unlock()
+		}
+		m.sched()
+	})
+}
+
+// FireDueWithin executes all Timers and Tickers that are due within epsilon of the internal time, including the
+// ones that are already due, without changing the internal time. It returns the number of executions. This can be
+// used to fire events whose due time was calculated with rounding errors and lands just after the internal time.
+// If FireDueWithin is queued because it is called from a callback, it returns 0.
+func (m *Mock) FireDueWithin(epsilon time.Duration) int {
+	if epsilon < 0 {
+		epsilon = -epsilon
+	}
+	n := 0
+	m.serialize(func() {
+		for m.tickNext(epsilon) {
+			n++
+		}
+		m.sched()
+	})
+	return n
+}
+
 // tick sends an event to all tickers and timers informing them that time has changed.
-func (m *Mock) tick(t time.Time) {
-	for m.tickNext(t) {
+// The queue is sorted anew before every execution, so Executers that are added while others execute are picked up.
+// Nothing is executed while the Mock is frozen.
+func (m *Mock) tick() {
+	m.mu.RLock()
+	frozen, busy := m.frozen, m.busyLocked()
+	m.mu.RUnlock()
+	if frozen {
+		return
+	}
+	for m.tickNext(0) {
+	}
+	if !busy {
+		return
+	}
+	m.mu.RLock()
+	hook := m.idle
+	idle := !m.busyLocked()
+	m.mu.RUnlock()
+	if hook != nil && idle {
+		hook()
+		// Fire what the hook has created due, e.g. with AfterFunc(0)
+		for m.tickNext(0) {
+		}
+	}
+}
+
+// serialize runs step while holding advanceMu, so it doesn't interleave with other calls that move the internal time
+// or replace Timers and Tickers. If the calling goroutine is running a step already, i.e. step is requested by one of
+// its callbacks, step is queued to run right after the current step instead, since waiting for advanceMu would
+// deadlock. serialize returns false if step has been queued.
+func (m *Mock) serialize(step func()) bool {
+	if m.inStep() {
+		m.queueMu.Lock()
+		m.queued = append(m.queued, step)
+		m.queueMu.Unlock()
+		return false
+	}
+
+	m.advanceMu.Lock()
+	defer m.advanceMu.Unlock()
+	m.queueMu.Lock()
+	m.stepping, m.stepper = true, goroutineID()
+	m.queueMu.Unlock()
+	defer func() {
+		m.queueMu.Lock()
+		m.stepping = false
+		m.queued = nil
+		m.queueMu.Unlock()
+	}()
+	for {
+		step()
+		m.queueMu.Lock()
+		if len(m.queued) == 0 {
+			m.stepping = false
+			m.queueMu.Unlock()
+			return true
+		}
+		step = m.queued[0]
+		m.queued = m.queued[1:]
+		m.queueMu.Unlock()
+	}
+}
+
+// inStep reports whether the calling goroutine runs a serialized step, i.e. whether it is called from a callback
+// during Forward, Set or a similar call.
+func (m *Mock) inStep() bool {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+	return m.stepping && m.stepper == goroutineID()
+}
+
+// goroutineID returns the ID of the calling goroutine, as printed in the header of its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// busyLocked reports whether work is scheduled, i.e. a Timer is pending or, if configured with
+// WithIdleIncludingTickers, a Ticker. The caller must hold the lock.
+func (m *Mock) busyLocked() bool {
+	for _, t := range m.timers {
+		if isInternal(t) {
+			continue
+		}
+		if _, ok := t.(*fakeTicker); !ok || m.idleTickers {
+			return true
+		}
 	}
+	return false
 }
 
-// tickNext executes the next Timer or Ticker in the queue
-func (m *Mock) tickNext(t time.Time) bool {
+// tickNext executes the next Timer or Ticker in the queue that is due at the current internal time plus the given
+// tolerance. The internal time is read anew for every Executer, since executing an Executer might change it.
+func (m *Mock) tickNext(tolerance time.Duration) bool {
 	m.mu.Lock()
-	sort.Sort(m)
+	t := m.now
+	sort.Sort(schedule{m})
 	if len(m.timers) == 0 {
 		m.mu.Unlock()
 		return false
 	}
 	n := m.timers[0]
-	if n.NextExecution().After(t) {
+	due := n.NextExecution()
+	if due.After(t.Add(tolerance)) {
 		m.mu.Unlock()
 		return false
 	}
+	hook := m.beforeFire
 	m.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			panic(fmt.Sprintf("clock: executing %s: %v", describe(n, due), r))
+		}
+	}()
+	if hook != nil {
+		hook(n, due)
+	}
+	// An Executer that is due within the tolerance is executed at its due time
+	if due.After(t) {
+		t = due
+	}
 	n.Execute(t)
 	return true
 }
 
+// BeforeFire registers a hook that is called whenever a Timer, Ticker or other Executer of the Mock is about to be
+// executed, with the time it is due at. Only one hook can be registered, a later call replaces the hook and nil
+// removes it. The hook is called without holding the lock of the Mock, so it may call methods of the Mock. This can
+// be used to record the order in which a complex schedule fires or to inject faults.
+func (m *Mock) BeforeFire(hook func(e Executer, at time.Time)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.beforeFire = hook
+}
+
+// OnIdle registers a callback that is called whenever firing the Timers that became due, e.g. during Forward or Set,
+// leaves no more scheduled work, i.e. when the last pending Timer is gone. This signals that a simulation has become
+// quiescent and can e.g. drive its teardown. By default, Tickers are not considered scheduled work since they run
+// forever, use WithIdleIncludingTickers to change that. Only one callback can be registered, a later call replaces
+// it and nil removes it. The callback is called without holding the lock of the Mock. Like in AfterFunc callbacks,
+// calls that move the internal time are queued, see Mock.
+func (m *Mock) OnIdle(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idle = fn
+}
+
+// describe returns a human readable description of an Executer that is due at the given time. It is used to
+// identify the offending Timer or Ticker in panic messages.
+func describe(e Executer, due time.Time) string {
+	var kind, label string
+	switch v := e.(type) {
+	case *fakeTimer:
+		kind, label = "timer", v.Label()
+	case *fakeTicker:
+		kind, label = "ticker", v.Label()
+	default:
+		kind = fmt.Sprintf("%T", e)
+	}
+	if label != "" {
+		return fmt.Sprintf("%s %q due at %v", kind, label, due)
+	}
+	return fmt.Sprintf("%s due at %v", kind, due)
+}
+
 // Now returns the current internal time as either set by Set() or forwarded by Forward().
+// Now doesn't take the lock of the Mock, so it scales with many concurrent readers.
 func (m *Mock) Now() time.Time {
+	return m.current.Load().(time.Time)
+}
+
+// NowRaw returns the internal time exactly as it is stored by the Mock. Unlike Now, it takes the lock of the Mock.
+// The result is == to the result of Now at the same internal time, since neither carries a monotonic clock reading.
+func (m *Mock) NowRaw() time.Time {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.now
 }
 
+// setNow sets the internal time. The caller must hold the write lock.
+func (m *Mock) setNow(t time.Time) {
+	// Strip the monotonic clock reading, durations are computed from the internal time only
+	t = t.Round(0)
+	m.now = t
+	m.current.Store(t)
+}
+
 // After behaves like time.After. However, it only fires when the internal time is forwarded by at least d.
 func (m *Mock) After(d time.Duration) <-chan time.Time {
-	t := m.NewTimer(d)
+	t := m.NewTimer(d).(*fakeTimer)
+	t.mu.Lock()
+	t.waiter = true
+	t.mu.Unlock()
 	return t.Chan()
 }
 
+// AfterStoppable behaves like After, but also returns a function that stops the wait. Once stop has been called,
+// nothing is sent on the channel, even if the internal time is forwarded past d. stop returns true if it stopped the
+// wait and false if the time has already been sent or the wait has already been stopped.
+func (m *Mock) AfterStoppable(d time.Duration) (ch <-chan time.Time, stop func() bool) {
+	t := m.NewTimer(d)
+	return t.Chan(), t.Stop
+}
+
+// AfterAny behaves like After with the shortest of the durations. If no duration is given, nothing is ever sent.
+func (m *Mock) AfterAny(ds ...time.Duration) <-chan time.Time {
+	if len(ds) == 0 {
+		return make(chan time.Time)
+	}
+	return m.After(shortest(ds))
+}
+
+// AfterAll behaves like After with the longest of the durations. If no duration is given, the time is sent
+// immediately.
+func (m *Mock) AfterAll(ds ...time.Duration) <-chan time.Time { return m.After(longest(ds)) }
+
 // AfterFunc waits for the duration to elapse and then executes a function.
 // A Timer is returned that can be stopped.
+// If d <= 0, the function is executed without the internal time having to be forwarded, like NewTimer fires. Like
+// with time.AfterFunc, it runs in its own goroutine then, or, if AfterFunc is called from a callback during Forward or
+// a similar call, before that call returns.
 func (m *Mock) AfterFunc(d time.Duration, fn func()) Timer {
 	t := m.fakeTimer(d)
+	// Make sure the callback is locked. It might be read on Execute before we even assign it
+	t.mu.Lock()
 	t.fn = fn
-	sched()
+	t.mu.Unlock()
+	if d <= 0 {
+		m.fireDue(t)
+	}
+	m.sched()
 	return t
 }
 
-// Since returns the time elapsed since t in comparison to the internal time.
-func (m *Mock) Since(t time.Time) time.Duration { return m.Now().Sub(t) }
+// NowUnix returns the internal time as the number of seconds elapsed since the Unix epoch.
+func (m *Mock) NowUnix() int64 { return m.Now().Unix() }
+
+// NowUnixMilli returns the internal time as the number of milliseconds elapsed since the Unix epoch.
+func (m *Mock) NowUnixMilli() int64 { return m.Now().UnixMilli() }
+
+// NowUnixNano returns the internal time as the number of nanoseconds elapsed since the Unix epoch.
+func (m *Mock) NowUnixNano() int64 { return m.Now().UnixNano() }
 
-// Until returns the duration until t in comparison to the internal time.
-func (m *Mock) Until(t time.Time) time.Duration { return t.Sub(m.Now()) }
+// NowString returns the internal time formatted according to layout. The time is formatted in the location of the
+// internal time, which can be chosen with NewMockAt or Set.
+func (m *Mock) NowString(layout string) string { return m.Now().Format(layout) }
+
+// Since returns the time elapsed since t in comparison to the internal time. It is negative if t is after the
+// internal time. Since(t) == -Until(t) always holds: like time.Time.Sub, the result saturates if it overflows a
+// Duration, but it is clamped to -math.MaxInt64 instead of math.MinInt64 so it can be negated.
+func (m *Mock) Since(t time.Time) time.Duration {
+	d := m.Now().Sub(t)
+	if d == math.MinInt64 {
+		d = -math.MaxInt64
+	}
+	return d
+}
+
+// Until returns the duration until t in comparison to the internal time. It is negative if t is before the internal
+// time. Until(t) == -Since(t) always holds.
+func (m *Mock) Until(t time.Time) time.Duration { return -m.Since(t) }
 
 // Sleep pauses the current goroutine for at least the duration d in comparison to the internal time.
 func (m *Mock) Sleep(d time.Duration) {
 	<-m.After(d)
 }
 
+// SleepContext pauses the current goroutine for at least the duration d in comparison to the internal time or until
+// ctx is done. If the internal time is forwarded past d before ctx is cancelled, SleepContext returns nil, even if
+// the goroutine wakes up only after the cancellation.
+func (m *Mock) SleepContext(ctx context.Context, d time.Duration) error {
+	return sleepContext(ctx, m.NewTimer(d))
+}
+
+// WaitTimeout waits for wg until the internal time has been forwarded by d. It returns true if wg completed before.
+func (m *Mock) WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool {
+	return waitTimeout(wg, m.NewTimer(d))
+}
+
+// SleepUntilNext pauses the current goroutine until the internal time reaches the next multiple of period since the
+// Unix epoch.
+func (m *Mock) SleepUntilNext(period time.Duration) {
+	if d := untilNext(m.Now(), period); d > 0 {
+		m.Sleep(d)
+	}
+}
+
+// Jitter returns a random duration between base and base+factor*base. The Mock draws from its own seeded source, so
+// the sequence of jitters is reproducible. The seed can be configured with WithJitterSeed.
+func (m *Mock) Jitter(base time.Duration, factor float64) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return jitter(base, factor, m.jitter.Float64)
+}
+
 // NewTicker returns a new Ticker containing a channel that will send the
 // time with a period specified by the duration argument.
+// The values sent are the scheduled tick times start+d, start+2d, ..., where start is the internal time at the
+// creation of the Ticker. Dropped ticks don't shift the phase of the following ones.
+// Like time.NewTicker, it panics if d <= 0.
 func (m *Mock) NewTicker(d time.Duration) Ticker {
+	return m.NewBufferedTicker(d, 1)
+}
+
+// NewTickerImmediate returns a new Ticker whose first tick is due at the internal time, so it is sent on the next
+// Forward or Set, even Forward(0). The following ticks are sent every d.
+// Like time.NewTicker, it panics if d <= 0.
+func (m *Mock) NewTickerImmediate(d time.Duration) Ticker {
+	return m.NewTickerAt(m.Now(), d)
+}
+
+// NewCoalescingTicker behaves like NewTicker, but ticks that are less than window after the last delivered tick are
+// suppressed. This models rate-limited event sources: no matter how the internal time is forwarded, at most one tick
+// is delivered per window. The phase of the Ticker is not affected by suppressed ticks.
+// Like time.NewTicker, it panics if d <= 0.
+func (m *Mock) NewCoalescingTicker(d, window time.Duration) Ticker {
+	t := m.NewTicker(d).(*fakeTicker)
+	t.mu.Lock()
+	t.window = window
+	t.mu.Unlock()
+	return t
+}
+
+// NewLabeledTicker behaves like NewTicker, but the Ticker carries label. The label is reported by the Label method
+// of the Ticker, by PendingTimers and in panic messages.
+func (m *Mock) NewLabeledTicker(d time.Duration, label string) Ticker {
+	t := m.NewTicker(d).(*fakeTicker)
+	t.mu.Lock()
+	t.label = label
+	t.mu.Unlock()
+	return t
+}
+
+// TryNewTicker behaves like NewTicker, but returns ErrNonPositiveInterval instead of panicking if d <= 0.
+func (m *Mock) TryNewTicker(d time.Duration) (Ticker, error) {
+	if d <= 0 {
+		return nil, ErrNonPositiveInterval
+	}
+	return m.NewTicker(d), nil
+}
+
+// NewBufferedTicker behaves like NewTicker, but the channel of the Ticker can hold up to buffer ticks. When the
+// internal time is forwarded across multiple periods, up to buffer ticks are queued and only the remaining ones are
+// dropped. This can be used to test consumers that catch up on a backlog of ticks.
+// A buffer smaller than 1 is treated as 1.
+func (m *Mock) NewBufferedTicker(d time.Duration, buffer int) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return m.fakeTicker(m.Now().Add(d), d, buffer)
+}
+
+// NewTickerAt returns a new Ticker that sends the time on its channel at start and then with a period specified by
+// the duration argument. If start is not after the internal time, the first tick is due immediately and fires on
+// the next Forward or Set.
+// Like time.NewTicker, it panics if d <= 0.
+func (m *Mock) NewTickerAt(start time.Time, d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	if now := m.Now(); start.Before(now) {
+		start = now
+	}
+	return m.fakeTicker(start, d, 1)
+}
+
+// Every calls fn every d in comparison to the internal time until the returned cancel function is called. Like with
+// AfterFunc, fn is called synchronously while the internal time is moved, once for every period that has elapsed.
+// fn may call cancel itself.
+func (m *Mock) Every(d time.Duration, fn func()) func() {
+	if d <= 0 {
+		panic("non-positive interval for Every")
+	}
+	t := &fakeTicker{clock: m, d: d, next: m.Now().Add(d), fn: fn}
+	m.addTimer(t)
+	return t.Stop
+}
+
+// fakeTicker returns a fakeTicker object with its first tick at next and a channel that can hold buffer ticks.
+// A buffer smaller than 1 is treated as 1.
+func (m *Mock) fakeTicker(next time.Time, d time.Duration, buffer int) *fakeTicker {
+	if buffer < 1 {
+		buffer = 1
+	}
 	t := fakeTicker{}
-	t.ch = make(chan time.Time, 1)
+	t.ch = make(chan time.Time, buffer)
 	t.clock = m
 	t.d = d
-	t.next = m.Now().Add(d)
+	t.next = next
 	m.addTimer(&t)
 	return &t
 }
 
+// NewChaosTicker returns a new Ticker whose intervals are random durations between min and max inclusive, drawn from
+// rng. This can be used to test periodic consumers against an irregular cadence. With a seeded rng, the intervals
+// are reproducible. rng must not be used elsewhere while the Ticker is running.
+// It panics if min <= 0 or max < min.
+func (m *Mock) NewChaosTicker(min, max time.Duration, rng *rand.Rand) Ticker {
+	if min <= 0 {
+		panic("clock: non-positive interval for NewChaosTicker")
+	}
+	if max < min {
+		panic("clock: max interval smaller than min interval for NewChaosTicker")
+	}
+	interval := func() time.Duration {
+		return min + time.Duration(rng.Int63n(int64(max-min)+1))
+	}
+	t := &fakeTicker{}
+	t.ch = make(chan time.Time, 1)
+	t.clock = m
+	t.d = min
+	t.interval = interval
+	t.next = m.Now().Add(interval())
+	m.addTimer(t)
+	return t
+}
+
+// SetTickerPolicy sets the TickerPolicy of all Tickers that don't have their own policy set with
+// SetTickerPolicyFor. The default policy is CatchUp.
+func (m *Mock) SetTickerPolicy(p TickerPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tickerPolicy = p
+}
+
+// TickerPolicy returns the TickerPolicy of all Tickers that don't have their own policy.
+func (m *Mock) TickerPolicy() TickerPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tickerPolicy
+}
+
+// SetTickerPolicyFor sets the TickerPolicy of a single Ticker created by the Mock, overriding the policy set with
+// SetTickerPolicy. It has no effect on other Tickers.
+func (m *Mock) SetTickerPolicyFor(t Ticker, p TickerPolicy) {
+	f, ok := t.(*fakeTicker)
+	if !ok {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.policy = &p
+}
+
+// NewTickerContext returns a new Ticker that is stopped when ctx is done. If ctx is already done, the Ticker never
+// ticks, no matter how far the internal time is forwarded. Once ctx is done, the Ticker doesn't tick anymore, even if
+// the internal time is forwarded before the Ticker has been stopped in the background.
+func (m *Mock) NewTickerContext(ctx context.Context, d time.Duration) Ticker {
+	t := m.NewTicker(d).(*fakeTicker)
+	t.mu.Lock()
+	t.ctx = ctx
+	t.mu.Unlock()
+	return newContextTicker(ctx, t)
+}
+
+// NewTickerChan returns a new Ticker and its channel.
+func (m *Mock) NewTickerChan(d time.Duration) (Ticker, <-chan time.Time) {
+	t := m.NewTicker(d)
+	return t, t.Chan()
+}
+
 // NewTimer creates a new Timer that will send
 // the current time on its channel after at least duration d.
+// If d <= 0, the Timer fires immediately without the internal time having to be forwarded.
 func (m *Mock) NewTimer(d time.Duration) Timer {
 	t := m.fakeTimer(d)
 	// Make sure the channel is locked. It might be read on Execute before we even assign it
 	m.mu.Lock()
 	t.ch = make(chan time.Time, 1)
 	m.mu.Unlock()
+	if d <= 0 {
+		m.fireDue(t)
+	}
 	return t
 }
 
+// fireDue fires t, which is due at the internal time, without waiting for the internal time to be moved, like the
+// time package does for non-positive durations. A channel Timer sends its value right away. The function of a Timer
+// created by AfterFunc never runs in the caller's goroutine, which might hold locks the function needs: if the caller
+// is a callback during Forward or a similar call, the Timer is fired by that call before it returns, otherwise the
+// function runs in its own goroutine like with time.AfterFunc.
+func (m *Mock) fireDue(t *fakeTimer) {
+	t.mu.RLock()
+	hasFunc := t.ch == nil
+	t.mu.RUnlock()
+	switch {
+	case !hasFunc:
+		t.Execute(t.NextExecution())
+	case m.inStep():
+		// The tick loop of the running step picks the Timer up
+	default:
+		go t.Execute(t.NextExecution())
+	}
+}
+
+// TimerSpec describes a Timer that is created by Mock.Schedule.
+type TimerSpec struct {
+	// After is the duration after which the Timer fires
+	After time.Duration
+	// Func is called when the Timer fires. If Func is nil, the Timer sends the time on its channel instead.
+	Func func()
+}
+
+// Schedule creates a Timer for every spec and returns them in the same order. All Timers are added at once, so a
+// concurrent Forward or Set either fires none or all of them that are due, and their due times are relative to the
+// same internal time. Timers are created like NewTimer or AfterFunc would, depending on whether Func is set.
+func (m *Mock) Schedule(specs ...TimerSpec) []Timer {
+	timers := make([]*fakeTimer, len(specs))
+	m.mu.Lock()
+	now := m.now
+	for i, spec := range specs {
+		t := &fakeTimer{clock: m, fn: spec.Func}
+		if spec.Func == nil {
+			t.ch = make(chan time.Time, 1)
+		}
+		d := spec.After
+		// A Timer can't be due before the current time
+		if d < 0 {
+			d = 0
+		}
+		t.due = now.Add(d)
+		m.addTimerLocked(t)
+		timers[i] = t
+	}
+	m.mu.Unlock()
+
+	result := make([]Timer, len(timers))
+	for i, t := range timers {
+		m.record(t, opRecord{op: OpCreate})
+		m.logEvent(TimerCreated, now, t)
+		if specs[i].After <= 0 {
+			m.fireDue(t)
+		}
+		result[i] = t
+	}
+	return result
+}
+
+// NewLabeledTimer behaves like NewTimer, but the Timer carries label. The label is reported by the Label method of
+// the Timer, by PendingTimers and in panic messages, which helps telling Timers apart in complex schedules.
+func (m *Mock) NewLabeledTimer(d time.Duration, label string) Timer {
+	t := m.NewTimer(d).(*fakeTimer)
+	t.mu.Lock()
+	t.label = label
+	t.mu.Unlock()
+	return t
+}
+
+// NewTimerReset creates a new Timer and a function that safely resets it.
+func (m *Mock) NewTimerReset(d time.Duration) (Timer, func(time.Duration)) {
+	t := m.NewTimer(d)
+	return t, resetter(t)
+}
+
+// NewTimerAt creates a new Timer that will send the time on its channel when the internal time reaches t.
+// If t is not after the internal time, the Timer fires immediately like a Timer created by NewTimer with a
+// non-positive duration.
+func (m *Mock) NewTimerAt(t time.Time) Timer {
+	return m.NewTimer(t.Sub(m.Now()))
+}
+
+// NewTimerCapped creates a new Timer that fires when the internal time has been forwarded by d, but no later than
+// when it reaches notAfter. If the internal time has already reached notAfter, the Timer fires immediately, like a
+// Timer created with a non-positive duration.
+func (m *Mock) NewTimerCapped(d time.Duration, notAfter time.Time) Timer {
+	return m.NewTimer(capped(d, m.Until(notAfter)))
+}
+
 // fakeTimer returns a fakeTimer object with some standard setup
 func (m *Mock) fakeTimer(d time.Duration) *fakeTimer {
 	t := fakeTimer{}
 	// Set this to nil expressively to show that this Timer will not do anything
 	t.ch = nil
 	t.fn = nil
-	t.due = m.Now().Add(d)
+	// A Timer can't be due before the current time
+	if d < 0 {
+		d = 0
+	}
+	now := m.Now()
+	t.due = now.Add(d)
 	t.clock = m
 
 	m.addTimer(&t)
+	m.record(&t, opRecord{op: OpCreate})
+	m.logEvent(TimerCreated, now, &t)
 	return &t
 }
 
 // removeTimer removes a given Executer from the list of timers
-func (m *Mock) removeTimer(t Executer) {
+func (m *Mock) removeTimer(t tracked) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	for i, ti := range m.timers {
-		if t == ti {
-			m.timers[i] = m.timers[len(m.timers)-1]
-			m.timers[len(m.timers)-1] = nil
-			m.timers = m.timers[:len(m.timers)-1]
-			return
-		}
+	m.removeTimerLocked(t)
+}
+
+// removeTimerLocked removes a given Executer from the list of timers. The caller must hold the write lock.
+func (m *Mock) removeTimerLocked(t tracked) {
+	i := t.track().index
+	// The index is stale if the Executer isn't tracked anymore
+	if i < 0 || i >= len(m.timers) || m.timers[i] != t {
+		return
 	}
+	last := len(m.timers) - 1
+	m.timers[i] = m.timers[last]
+	m.timers[i].track().index = i
+	m.timers[last] = nil
+	m.timers = m.timers[:last]
 }
 
 // addTimer adds an Executer to the list of timers
-func (m *Mock) addTimer(t Executer) {
+func (m *Mock) addTimer(t tracked) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.addTimerLocked(t)
+}
+
+// addTimerLocked adds an Executer to the list of timers. The caller must hold the write lock.
+func (m *Mock) addTimerLocked(t tracked) {
+	m.seq++
+	t.track().seq = m.seq
+	t.track().index = len(m.timers)
 	m.timers = append(m.timers, t)
 }
 
+// resetter returns a function that resets t following the protocol documented for Timer.Reset: the Timer is stopped
+// and, if it has expired already, a value that hasn't been received is drained.
+func resetter(t Timer) func(time.Duration) {
+	return func(d time.Duration) {
+		if !t.Stop() {
+			select {
+			case <-t.Chan():
+			default:
+			}
+		}
+		t.Reset(d)
+	}
+}
+
+// sleepContext waits until t fires or ctx is done. A Timer that has already fired wins over ctx.
+func sleepContext(ctx context.Context, t Timer) error {
+	select {
+	case <-t.Chan():
+		return nil
+	case <-ctx.Done():
+	}
+	if !t.Stop() {
+		select {
+		case <-t.Chan():
+			return nil
+		default:
+		}
+	}
+	return ctx.Err()
+}
+
+// waitTimeout waits for wg until t fires. It returns true if wg completed first, in which case t is stopped. If both
+// happen at once, wg wins.
+func waitTimeout(wg *sync.WaitGroup, t Timer) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Stop()
+		return true
+	case <-t.Chan():
+	}
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// capped returns d, but at most until.
+func capped(d, until time.Duration) time.Duration {
+	if until < d {
+		return until
+	}
+	return d
+}
+
+// jitter returns a random duration between base and base+factor*base, using rnd as the source of randomness.
+func jitter(base time.Duration, factor float64, rnd func() float64) time.Duration {
+	if factor <= 0 {
+		return base
+	}
+	return base + time.Duration(rnd()*factor*float64(base))
+}
+
+// shortest returns the shortest of the given durations. ds must not be empty.
+func shortest(ds []time.Duration) time.Duration {
+	min := ds[0]
+	for _, d := range ds[1:] {
+		if d < min {
+			min = d
+		}
+	}
+	return min
+}
+
+// longest returns the longest of the given durations, or 0 if ds is empty.
+func longest(ds []time.Duration) time.Duration {
+	var max time.Duration
+	for _, d := range ds {
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// untilNext returns the duration from now until the next multiple of period since the Unix epoch. If now is exactly
+// on such a multiple, the full period is returned. A non-positive period results in a zero duration.
+func untilNext(now time.Time, period time.Duration) time.Duration {
+	if period <= 0 {
+		return 0
+	}
+	rem := time.Duration(now.UnixNano() % int64(period))
+	if rem < 0 {
+		rem += period
+	}
+	return period - rem
+}
+
+// sched suspends the current goroutine with the function configured by WithYield, or sched if there is none.
+func (m *Mock) sched() {
+	if m.yield != nil {
+		m.yield()
+		return
+	}
+	sched()
+}
+
 // sched suspends the current goroutine.
 //
 // runtime.Gosched() was previously used, but runtime.Gosched() calls the scheduler without suspending the calling function.
@@ -1,6 +1,10 @@
 package clock
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -8,6 +12,95 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestNewMockAt(t *testing.T) {
+	start := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewMockAt(start)
+	assert.Equal(t, start, clock.Now())
+
+	after := clock.After(time.Hour)
+	clock.Forward(59 * time.Minute)
+	assert.Empty(t, after)
+	clock.Forward(time.Minute)
+	assert.Equal(t, start.Add(time.Hour), <-after)
+
+	clock.Reset()
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestMock_Monotonic(t *testing.T) {
+	// A time returned by time.Now carries a monotonic clock reading
+	start := time.Now()
+	assert.NotEqual(t, start.String(), start.Round(0).String())
+
+	clock := NewMockAt(start)
+	assert.Equal(t, start.Round(0).String(), clock.Now().String())
+	assert.Equal(t, clock.Now(), clock.Now().Round(0))
+
+	// Moving the internal time backwards is reflected in Since and Until, no matter whether the monotonic reading
+	// of the argument has been stripped
+	clock.Set(start.Add(-time.Hour))
+	assert.Equal(t, -time.Hour, clock.Since(start))
+	assert.Equal(t, -time.Hour, clock.Since(start.Round(0)))
+	assert.Equal(t, time.Hour, clock.Until(start))
+	assert.Equal(t, time.Hour, clock.Until(start.Round(0)))
+}
+
+func TestMock_NowRaw(t *testing.T) {
+	clock := NewMockAt(time.Now())
+	a, b := clock.Now(), clock.Now()
+	assert.True(t, a == b)
+	assert.True(t, clock.NowRaw() == a)
+
+	timer := clock.NewTimer(time.Second)
+	after := clock.After(time.Second)
+	clock.Forward(time.Second)
+	assert.True(t, clock.Now() == a.Add(time.Second))
+	assert.True(t, clock.NowRaw() == clock.Now())
+	assert.True(t, <-timer.Chan() == clock.Now())
+	assert.True(t, <-after == clock.Now())
+	assert.Equal(t, time.Second, clock.Since(a))
+	assert.Equal(t, -time.Second, clock.Until(a))
+}
+
+func TestMock_FakeClock(t *testing.T) {
+	var clock FakeClock = NewMock()
+	advance := func(c FakeClock) {
+		c.Forward(time.Minute)
+		c.RunUntilDone()
+	}
+	var fired int32
+	clock.AfterFunc(time.Second, func() { atomic.AddInt32(&fired, 1) })
+	clock.AfterFunc(time.Hour, func() { atomic.AddInt32(&fired, 1) })
+	assert.Equal(t, 2, clock.Len())
+
+	advance(clock)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fired))
+	assert.Equal(t, time.Unix(3600, 0), clock.Now())
+}
+
+func TestMock_NowUnix(t *testing.T) {
+	clock := NewMockAt(time.Date(2020, 2, 3, 4, 5, 6, 789123456, time.UTC))
+	for i := 0; i < 3; i++ {
+		now := clock.Now()
+		assert.Equal(t, now.Unix(), clock.NowUnix())
+		assert.Equal(t, now.UnixMilli(), clock.NowUnixMilli())
+		assert.Equal(t, now.UnixNano(), clock.NowUnixNano())
+		clock.Forward(1500 * time.Millisecond)
+	}
+	assert.Equal(t, int64(1580702711), clock.NowUnix())
+	assert.Equal(t, int64(1580702711289), clock.NowUnixMilli())
+	assert.Equal(t, int64(1580702711289123456), clock.NowUnixNano())
+}
+
+func TestMock_NowString(t *testing.T) {
+	clock := NewMockAt(time.Date(2020, 2, 3, 4, 5, 6, 0, time.FixedZone("CET", 3600)))
+	assert.Equal(t, "2020-02-03T04:05:06+01:00", clock.NowString(time.RFC3339))
+	clock.Forward(90 * time.Minute)
+	assert.Equal(t, "05:35:06 CET", clock.NowString("15:04:05 MST"))
+	clock.Set(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2021-01-01 00:00:00 +0000 UTC", clock.NowString("2006-01-02 15:04:05 -0700 MST"))
+}
+
 func TestMock_Forward(t *testing.T) {
 	c := NewMock()
 	n := c.Now()
@@ -37,6 +130,25 @@ func TestMock_RunUntilDone(t *testing.T) {
 	assert.Equal(t, int32(61), atomic.LoadInt32(&fired))
 }
 
+func TestMock_RunUntilDoneN(t *testing.T) {
+	c := NewMock()
+	ticker := c.NewBufferedTicker(time.Minute, 10)
+	var fired int32
+	c.AfterFunc(90*time.Second, func() { atomic.AddInt32(&fired, 1) })
+
+	c.RunUntilDoneN(3)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fired))
+	assert.Len(t, ticker.Chan(), 3)
+	assert.Equal(t, time.Unix(180, 0), c.Now())
+
+	// Tickers keep ticking while timers are pending
+	c.AfterFunc(5*time.Minute, func() { atomic.AddInt32(&fired, 1) })
+	c.RunUntilDoneN(2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fired))
+	assert.Len(t, ticker.Chan(), 8)
+	assert.Equal(t, time.Unix(480, 0), c.Now())
+}
+
 func TestMock_Since(t *testing.T) {
 	c := NewMock()
 	target := time.Unix(1000, 0)
@@ -53,6 +165,29 @@ func TestMock_Until(t *testing.T) {
 	assert.Equal(t, c.Until(target.Add(diff)), diff)
 }
 
+func TestMock_SinceUntilParity(t *testing.T) {
+	c := NewMockAt(time.Unix(1000, 0))
+	now := c.Now()
+	tests := []struct {
+		name  string
+		t     time.Time
+		since time.Duration
+	}{
+		{"past", now.Add(-time.Hour), time.Hour},
+		{"just past", now.Add(-time.Nanosecond), time.Nanosecond},
+		{"present", now, 0},
+		{"just future", now.Add(time.Nanosecond), -time.Nanosecond},
+		{"future", now.Add(time.Hour), -time.Hour},
+		{"far past", time.Time{}, math.MaxInt64},
+		{"far future", time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), -math.MaxInt64},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.since, c.Since(test.t), test.name)
+		assert.Equal(t, -test.since, c.Until(test.t), test.name)
+		assert.Equal(t, c.Since(test.t), -c.Until(test.t), test.name)
+	}
+}
+
 func TestMock_After(t *testing.T) {
 	received := int32(0)
 	clock := NewMock()
@@ -68,6 +203,21 @@ func TestMock_After(t *testing.T) {
 	assert.NotZero(t, atomic.LoadInt32(&received))
 }
 
+func TestMock_AfterStoppable(t *testing.T) {
+	clock := NewMock()
+	ch, stop := clock.AfterStoppable(time.Minute)
+	clock.Forward(30 * time.Second)
+	assert.True(t, stop())
+	assert.False(t, stop())
+	clock.Forward(time.Hour)
+	assert.Empty(t, ch)
+
+	ch, stop = clock.AfterStoppable(time.Minute)
+	clock.Forward(time.Minute)
+	assert.False(t, stop())
+	assert.Len(t, ch, 1)
+}
+
 func TestMock_AfterFunc(t *testing.T) {
 	received := int32(0)
 	clock := NewMock()
@@ -83,6 +233,23 @@ func TestMock_AfterFunc(t *testing.T) {
 
 }
 
+func TestMock_NonPositiveDuration(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		clock := NewMock()
+		timer := clock.NewTimer(d)
+		after := clock.After(d)
+		fired := make(chan time.Time)
+		// Like with time.AfterFunc, the function runs in its own goroutine
+		clock.AfterFunc(d, func() { fired <- clock.Now() })
+
+		// All three fire without forwarding the internal time
+		assert.Equal(t, time.Unix(0, 0), <-timer.Chan())
+		assert.Equal(t, time.Unix(0, 0), <-after)
+		assert.Equal(t, time.Unix(0, 0), <-fired)
+		assert.Equal(t, 0, clock.Len())
+	}
+}
+
 func TestMock_Sleep(t *testing.T) {
 	received := int32(0)
 	clock := NewMock()
@@ -99,3 +266,1059 @@ func TestMock_Sleep(t *testing.T) {
 	time.Sleep(time.Millisecond)
 	assert.NotZero(t, atomic.LoadInt32(&received))
 }
+
+func TestMock_SleepContext(t *testing.T) {
+	// The context is cancelled first
+	clock := NewMock()
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- clock.SleepContext(ctx, time.Minute) }()
+	sched()
+	clock.Forward(30 * time.Second)
+	cancel()
+	assert.Equal(t, context.Canceled, <-errs)
+	assert.Zero(t, clock.Len())
+
+	// The duration elapses first
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go func() { errs <- clock.SleepContext(ctx, time.Minute) }()
+	// Wait until the goroutine sleeps, otherwise the Forward would miss its timer
+	for clock.Len() == 0 {
+		sched()
+	}
+	clock.Forward(time.Minute)
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("SleepContext did not return")
+	}
+
+	// Both happen before the goroutine wakes up, the elapsed duration wins
+	for i := 0; i < 20; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		timer := clock.NewTimer(time.Minute)
+		clock.Forward(time.Minute)
+		cancel()
+		assert.NoError(t, sleepContext(ctx, timer))
+	}
+}
+
+func TestMock_InterruptSleeps(t *testing.T) {
+	clock := NewMock()
+	var woken int32
+	for i := 0; i < 3; i++ {
+		go func() {
+			clock.Sleep(time.Hour)
+			atomic.AddInt32(&woken, 1)
+		}()
+	}
+	after := clock.AfterAny(time.Minute, time.Hour)
+	timer := clock.NewTimer(time.Minute)
+	sched()
+
+	clock.InterruptSleeps()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&woken))
+	assert.Equal(t, time.Unix(0, 0), <-after)
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+	// Regular timers keep running
+	assert.Empty(t, timer.Chan())
+	assert.Equal(t, 1, clock.Len())
+}
+
+func panicMessage(fn func()) (msg string) {
+	defer func() {
+		msg = fmt.Sprint(recover())
+	}()
+	fn()
+	return ""
+}
+
+func TestMock_PanicMessage(t *testing.T) {
+	clock := NewMock()
+	timer := clock.AfterFunc(time.Minute, nil)
+	timer.(*fakeTimer).label = "cleanup"
+	due := clock.Now().Add(time.Minute)
+
+	msg := panicMessage(func() { clock.Forward(time.Hour) })
+	assert.Contains(t, msg, `timer "cleanup"`)
+	assert.Contains(t, msg, due.String())
+	assert.Contains(t, msg, "nil callback")
+
+	clock = NewMock()
+	clock.AfterFunc(time.Second, func() { panic("boom") })
+	due = clock.Now().Add(time.Second)
+	msg = panicMessage(func() { clock.Forward(time.Second) })
+	assert.Contains(t, msg, "timer due at "+due.String())
+	assert.Contains(t, msg, "boom")
+}
+
+func TestMock_Reset(t *testing.T) {
+	clock := NewMock()
+	var fired int32
+	timer := clock.NewTimer(time.Minute)
+	ticker := clock.NewTicker(time.Second)
+	clock.AfterFunc(time.Hour, func() { atomic.AddInt32(&fired, 1) })
+	clock.Forward(30 * time.Second)
+	assert.Equal(t, 3, clock.Len())
+
+	clock.Reset()
+	assert.Zero(t, clock.Len())
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+	assert.False(t, timer.Stop())
+
+	clock.Forward(2 * time.Hour)
+	assert.Zero(t, atomic.LoadInt32(&fired))
+	assert.Len(t, ticker.Chan(), 1)
+
+	// The Mock can be used for fresh scheduling
+	clock.AfterFunc(time.Second, func() { atomic.AddInt32(&fired, 1) })
+	clock.Forward(time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fired))
+}
+
+func TestMock_SetAndRebaseTickers(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(1000, 0))
+	ticker := clock.NewTicker(10 * time.Second)
+	timer := clock.NewTimer(10 * time.Second)
+
+	// Moving backwards keeps the phase: the ticker ticks at multiples of 10s after 1000s
+	clock.SetAndRebaseTickers(time.Unix(3, 0))
+	clock.Forward(6 * time.Second)
+	assert.Empty(t, ticker.Chan())
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(10, 0), <-ticker.Chan())
+	clock.Forward(10 * time.Second)
+	assert.Equal(t, time.Unix(20, 0), <-ticker.Chan())
+	// The timer still fires at its due time
+	assert.Empty(t, timer.Chan())
+
+	// Moving forwards skips the elapsed periods, a tick due at the new time fires
+	clock.SetAndRebaseTickers(time.Unix(1000, 0))
+	assert.Equal(t, time.Unix(1000, 0), <-ticker.Chan())
+	clock.SetAndRebaseTickers(time.Unix(1095, 0))
+	assert.Empty(t, ticker.Chan())
+	assert.Equal(t, time.Unix(1010, 0), <-timer.Chan())
+	clock.Forward(5 * time.Second)
+	assert.Equal(t, time.Unix(1100, 0), <-ticker.Chan())
+}
+
+func TestMock_WaitTimeout(t *testing.T) {
+	clock := NewMock()
+	for _, completes := range []bool{true, false} {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		result := make(chan bool, 1)
+		go func() { result <- clock.WaitTimeout(&wg, time.Second) }()
+		for len(clock.PendingTimers()) == 0 {
+			sched()
+		}
+		clock.Forward(999 * time.Millisecond)
+		if completes {
+			wg.Done()
+		} else {
+			clock.Forward(time.Millisecond)
+		}
+		assert.Equal(t, completes, <-result)
+		assert.Empty(t, clock.PendingTimers())
+		if !completes {
+			wg.Done()
+		}
+	}
+}
+
+func TestMock_State(t *testing.T) {
+	clock := NewMock()
+	const n = 20
+	for i := 1; i <= n; i++ {
+		clock.AfterFunc(time.Duration(i)*time.Second, func() {})
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			clock.Forward(time.Second)
+		}
+	}()
+	for {
+		now, pending := clock.State()
+		// Every second, exactly one timer fires
+		assert.Equal(t, n-int(now.Unix()), pending)
+		if pending == 0 {
+			break
+		}
+	}
+	<-done
+}
+
+func TestMock_ForwardWithBarrier(t *testing.T) {
+	clock := NewMock()
+	var mu sync.Mutex
+	var order []string
+	log := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, s)
+	}
+	clock.AfterFunc(time.Second, func() { log("timer") })
+
+	wake := make(chan struct{})
+	observed := make(chan struct{})
+	go func() {
+		<-wake
+		log("worker " + clock.Now().String())
+		close(observed)
+	}()
+	clock.ForwardWithBarrier(time.Second, func() {
+		close(wake)
+		<-observed
+	})
+
+	assert.Equal(t, []string{"worker " + time.Unix(1, 0).String(), "timer"}, order)
+	assert.Zero(t, clock.Len())
+}
+
+func TestMock_ForwardWithStops(t *testing.T) {
+	clock := NewMock()
+	count := 0
+	for i := 1; i <= 10; i++ {
+		clock.AfterFunc(time.Duration(i)*time.Minute, func() { count++ })
+	}
+	next := clock.ForwardWithStops(10*time.Minute,
+		time.Unix(0, 0).Add(7*time.Minute),
+		time.Unix(0, 0).Add(3*time.Minute),
+		time.Unix(0, 0).Add(time.Hour),
+	)
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+
+	var states []int
+	var times []time.Time
+	for next() {
+		states = append(states, count)
+		times = append(times, clock.Now())
+	}
+	assert.Equal(t, []int{3, 7}, states)
+	assert.Equal(t, []time.Time{time.Unix(0, 0).Add(3 * time.Minute), time.Unix(0, 0).Add(7 * time.Minute)}, times)
+	assert.Equal(t, 10, count)
+	assert.Equal(t, time.Unix(0, 0).Add(10*time.Minute), clock.Now())
+	assert.False(t, next())
+	assert.Equal(t, time.Unix(0, 0).Add(10*time.Minute), clock.Now())
+}
+
+func TestMock_Freeze(t *testing.T) {
+	clock := NewMock()
+	var order []int
+	for i := 3; i >= 1; i-- {
+		i := i
+		clock.AfterFunc(time.Duration(i)*time.Second, func() { order = append(order, i) })
+	}
+	timer := clock.NewTimer(2 * time.Second)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Freeze()
+	clock.Forward(2 * time.Second)
+	clock.Set(time.Unix(5, 0))
+	assert.Equal(t, time.Unix(5, 0), clock.Now())
+	assert.Empty(t, order)
+	assert.Empty(t, timer.Chan())
+	assert.Empty(t, ticker.Chan())
+	assert.Equal(t, 5, clock.Len())
+
+	clock.Unfreeze()
+	assert.Equal(t, []int{1, 2, 3}, order)
+	assert.Equal(t, time.Unix(2, 0), <-timer.Chan())
+	// Only the first tick fits into the buffer
+	assert.Equal(t, time.Unix(1, 0), <-ticker.Chan())
+	assert.Empty(t, ticker.Chan())
+	assert.Equal(t, 1, clock.Len())
+
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(6, 0), <-ticker.Chan())
+}
+
+func TestMock_OnIdle(t *testing.T) {
+	clock := NewMock()
+	idle := 0
+	clock.OnIdle(func() { idle++ })
+	ticker := clock.NewTicker(time.Second)
+	clock.AfterFunc(time.Second, func() {})
+	// A timer scheduled by a callback keeps the clock busy
+	clock.AfterFunc(2*time.Second, func() { clock.AfterFunc(time.Second, func() {}) })
+
+	clock.Forward(time.Second)
+	assert.Equal(t, 0, idle)
+	clock.Forward(time.Second)
+	assert.Equal(t, 0, idle)
+	clock.Forward(time.Second)
+	assert.Equal(t, 1, idle)
+	// Only the transition to idle is reported
+	clock.Forward(time.Second)
+	assert.Equal(t, 1, idle)
+
+	clock.NewTimer(time.Second)
+	clock.Forward(time.Second)
+	assert.Equal(t, 2, idle)
+	ticker.Stop()
+
+	// Tickers count as work if configured
+	clock = NewMock(WithIdleIncludingTickers())
+	idle = 0
+	clock.OnIdle(func() { idle++ })
+	ticker = clock.NewTicker(time.Second)
+	clock.AfterFunc(time.Second, func() { ticker.Stop() })
+	clock.NewTimer(time.Second)
+	clock.Forward(time.Second)
+	assert.Equal(t, 1, idle)
+}
+
+func TestMock_DurationToNext(t *testing.T) {
+	clock := NewMock()
+	_, ok := clock.DurationToNext()
+	assert.False(t, ok)
+
+	// The ticker is the soonest
+	ticker := clock.NewTicker(3 * time.Second)
+	timer := clock.NewTimer(5 * time.Second)
+	d, ok := clock.DurationToNext()
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	// The timer is the soonest
+	clock.Forward(4 * time.Second)
+	d, ok = clock.DurationToNext()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, d)
+	clock.Forward(d)
+	assert.Len(t, timer.Chan(), 1)
+
+	d, ok = clock.DurationToNext()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, d)
+	ticker.Stop()
+	_, ok = clock.DurationToNext()
+	assert.False(t, ok)
+}
+
+func TestMock_ForwardCalendar(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available:", err)
+	}
+	// Daylight saving time starts on 2021-03-14 at 2am
+	clock := NewMockAt(time.Date(2021, 3, 13, 12, 0, 0, 0, loc))
+	fired := clock.NewTimer(23 * time.Hour)
+	clock.ForwardCalendar(1, 0, 0)
+	assert.Equal(t, time.Date(2021, 3, 14, 12, 0, 0, 0, loc), clock.Now())
+	assert.Equal(t, 23*time.Hour, clock.ElapsedSinceStart())
+	assert.Len(t, fired.Chan(), 1)
+
+	clock.ForwardCalendar(0, 2, 1)
+	assert.Equal(t, time.Date(2021, 4, 14, 14, 0, 0, 0, loc), clock.Now())
+
+	assert.Panics(t, func() { clock.ForwardCalendar(-1, 0, 0) })
+	assert.Equal(t, time.Date(2021, 4, 14, 14, 0, 0, 0, loc), clock.Now())
+}
+
+func TestMock_Close(t *testing.T) {
+	clock := NewMock()
+	var wg sync.WaitGroup
+	read := func(ch <-chan time.Time) {
+		defer wg.Done()
+		for range ch {
+		}
+	}
+	ticker := clock.NewTicker(time.Second)
+	timer := clock.NewTimer(time.Hour)
+	clock.AfterFunc(time.Minute, func() {})
+	wg.Add(2)
+	go read(ticker.Chan())
+	go read(timer.Chan())
+	clock.Forward(3 * time.Second)
+
+	assert.NoError(t, clock.Close())
+	wg.Wait()
+	assert.Zero(t, clock.Len())
+	assert.EqualError(t, clock.Close(), "clock: Mock already closed")
+
+	// Closed timers stay stopped
+	assert.False(t, timer.Reset(time.Second))
+	clock.Forward(time.Hour)
+}
+
+func TestMock_SleepUntilNext(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Date(2019, 1, 1, 0, 0, 37, 0, time.UTC))
+	woke := make(chan time.Time, 1)
+	go func() {
+		clock.SleepUntilNext(time.Minute)
+		woke <- clock.Now()
+	}()
+	// Wait until the goroutine sleeps, otherwise the Forward would miss its timer
+	for clock.Len() == 0 {
+		sched()
+	}
+
+	clock.Forward(22 * time.Second)
+	assert.Empty(t, woke)
+	clock.Forward(time.Second)
+	select {
+	case now := <-woke:
+		assert.Equal(t, time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC), now)
+	case <-time.After(time.Second):
+		t.Fatal("SleepUntilNext did not return")
+	}
+}
+
+func TestMock_Jitter(t *testing.T) {
+	sequence := func(c *Mock) []time.Duration {
+		var ds []time.Duration
+		for i := 0; i < 10; i++ {
+			ds = append(ds, c.Jitter(time.Second, 0.5))
+		}
+		return ds
+	}
+	ds := sequence(NewMock(WithJitterSeed(42)))
+	assert.Equal(t, ds, sequence(NewMock(WithJitterSeed(42))))
+	assert.NotEqual(t, ds, sequence(NewMock(WithJitterSeed(7))))
+	assert.Equal(t, sequence(NewMock()), sequence(NewMock()))
+	for _, d := range ds {
+		assert.True(t, d >= time.Second && d < 1500*time.Millisecond, "jitter %v out of range", d)
+	}
+	assert.Equal(t, time.Second, NewMock().Jitter(time.Second, 0))
+}
+
+func TestUntilNext(t *testing.T) {
+	tests := []struct {
+		now    time.Time
+		period time.Duration
+		want   time.Duration
+	}{
+		{time.Date(2019, 1, 1, 0, 0, 37, 0, time.UTC), time.Minute, 23 * time.Second},
+		{time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC), time.Minute, time.Minute},
+		{time.Date(2019, 1, 1, 10, 30, 0, 0, time.UTC), time.Hour, 30 * time.Minute},
+		{time.Unix(-10, 0), time.Minute, 10 * time.Second},
+		{time.Unix(10, 0), 0, 0},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, untilNext(test.now, test.period))
+	}
+}
+
+func TestMock_SimulatedVsReal(t *testing.T) {
+	clock := NewMock()
+	clock.Forward(24 * time.Hour)
+	clock.Set(clock.Now().Add(time.Hour))
+	clock.Set(clock.Now().Add(-time.Hour))
+
+	simulated, real := clock.SimulatedVsReal()
+	assert.Equal(t, 25*time.Hour, simulated)
+	assert.True(t, real > 0)
+	assert.True(t, real < time.Minute)
+}
+
+func TestMock_Drain(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Minute)
+	ticker := clock.NewTicker(time.Second)
+	_, ok := clock.DrainTimer(timer)
+	assert.False(t, ok)
+	_, ok = clock.DrainTicker(ticker)
+	assert.False(t, ok)
+
+	clock.Forward(time.Minute)
+	v, ok := clock.DrainTimer(timer)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(60, 0), v)
+	v, ok = clock.DrainTicker(ticker)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1, 0), v)
+	_, ok = clock.DrainTimer(timer)
+	assert.False(t, ok)
+}
+
+func TestMock_AssertNoFire(t *testing.T) {
+	clock := NewMock()
+	late := clock.NewTimer(10 * time.Minute)
+	assert.True(t, clock.AssertNoFire(late.Chan(), 5*time.Minute))
+	assert.Equal(t, time.Unix(300, 0), clock.Now())
+
+	early := clock.NewTimer(time.Minute)
+	assert.False(t, clock.AssertNoFire(early.Chan(), 5*time.Minute))
+	assert.False(t, clock.AssertNoFire(late.Chan(), 5*time.Minute))
+}
+
+func TestMock_FireAll(t *testing.T) {
+	clock := NewMock()
+	var fired []int
+	clock.AfterFunc(time.Hour, func() { fired = append(fired, 2) })
+	clock.AfterFunc(time.Minute, func() { fired = append(fired, 1) })
+	timer := clock.NewTimer(24 * time.Hour)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.FireAll()
+	assert.Equal(t, []int{1, 2}, fired)
+	assert.Equal(t, time.Unix(86400, 0), <-timer.Chan())
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+	// Only the ticker is left
+	assert.Equal(t, 1, clock.Len())
+	assert.Empty(t, ticker.Chan())
+}
+
+func TestMock_ElapsedSinceStart(t *testing.T) {
+	clock := NewMockAt(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC))
+	clock.Forward(time.Hour)
+	clock.Forward(30 * time.Minute)
+	assert.Equal(t, 90*time.Minute, clock.ElapsedSinceStart())
+	clock.Set(time.Date(2019, 5, 31, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, -time.Hour, clock.ElapsedSinceStart())
+}
+
+func TestMock_ConcurrentForward(t *testing.T) {
+	clock := NewMock()
+	var mu sync.Mutex
+	var fired []time.Time
+	for i := 1; i <= 200; i++ {
+		clock.AfterFunc(time.Duration(i)*time.Second, func() {
+			mu.Lock()
+			fired = append(fired, clock.Now())
+			mu.Unlock()
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				clock.Forward(10 * time.Second)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, fired, 200)
+	for i := 1; i < len(fired); i++ {
+		assert.False(t, fired[i].Before(fired[i-1]), "fire %d at %v before %v", i, fired[i], fired[i-1])
+	}
+}
+
+func TestMock_ForwardFromCallback(t *testing.T) {
+	clock := NewMock()
+	var fired []time.Time
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, clock.Now())
+		clock.Forward(time.Second)
+		clock.AfterFunc(time.Second, func() {
+			fired = append(fired, clock.Now())
+			clock.Set(time.Unix(10, 0))
+		})
+	})
+	clock.AfterFunc(3*time.Second, func() { fired = append(fired, clock.Now()) })
+
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(10, 0), clock.Now())
+	assert.Equal(t, []time.Time{time.Unix(1, 0), time.Unix(2, 0), time.Unix(10, 0)}, fired)
+	assert.Equal(t, 0, clock.Len())
+}
+
+func TestMock_ForwardWhileCallbackRuns(t *testing.T) {
+	clock := NewMock()
+	running := make(chan struct{})
+	release := make(chan struct{})
+	clock.AfterFunc(time.Second, func() {
+		close(running)
+		<-release
+	})
+	go clock.Forward(time.Second)
+	<-running
+
+	// A Forward from another goroutine waits for the callback instead of being queued
+	forwarded := make(chan time.Time)
+	go func() {
+		clock.Forward(time.Hour)
+		forwarded <- clock.Now()
+	}()
+	sched()
+	close(release)
+	assert.Equal(t, time.Unix(3601, 0), <-forwarded)
+}
+
+func TestMock_ConcurrentReplace(t *testing.T) {
+	clock := NewMock()
+	s := clock.Snapshot()
+	var wg sync.WaitGroup
+	run := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				fn()
+			}
+		}()
+	}
+	run(func() {
+		clock.AfterFunc(time.Second, func() {})
+		clock.Forward(time.Second)
+	})
+	run(func() {
+		clock.After(time.Second)
+		clock.InterruptSleeps()
+	})
+	run(clock.Reset)
+	run(func() { clock.Restore(s) })
+	wg.Wait()
+
+	assert.NoError(t, clock.Close())
+	assert.Equal(t, 0, clock.Len())
+	assert.EqualError(t, clock.Close(), "clock: Mock already closed")
+}
+
+func TestMock_TimerAddedDuringTick(t *testing.T) {
+	clock := NewMock()
+	var fired []string
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, "first")
+		clock.AfterFunc(0, func() { fired = append(fired, "follow-up") })
+		clock.AfterFunc(time.Second, func() { fired = append(fired, "late") })
+	})
+	clock.AfterFunc(1500*time.Millisecond, func() { fired = append(fired, "second") })
+
+	// The follow-up is due at the new internal time and fires during the same Forward
+	clock.Forward(2 * time.Second)
+	assert.Equal(t, []string{"first", "second", "follow-up"}, fired)
+	clock.Forward(time.Second)
+	assert.Equal(t, []string{"first", "second", "follow-up", "late"}, fired)
+}
+
+func TestMock_ChangedAfterSelection(t *testing.T) {
+	// The hook runs after the timer has been picked for execution
+	clock := NewMock()
+	var fired int32
+	timer := clock.AfterFunc(time.Second, func() { atomic.AddInt32(&fired, 1) })
+	clock.BeforeFire(func(Executer, time.Time) { timer.Reset(time.Minute) })
+	clock.Forward(time.Second)
+	assert.Zero(t, atomic.LoadInt32(&fired))
+	clock.BeforeFire(nil)
+	clock.Forward(time.Minute)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fired))
+
+	ticker := clock.NewTicker(time.Second)
+	clock.BeforeFire(func(Executer, time.Time) { ticker.Stop() })
+	clock.Forward(time.Second)
+	assert.Empty(t, ticker.Chan())
+}
+
+func TestMock_ConcurrentChurn(t *testing.T) {
+	clock := NewMock()
+	var postStop int32
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				var stopped int32
+				timer := clock.AfterFunc(time.Duration(j%5)*time.Millisecond, func() {
+					if atomic.LoadInt32(&stopped) == 1 {
+						atomic.AddInt32(&postStop, 1)
+					}
+				})
+				if j%3 == 0 {
+					timer.Reset(time.Duration(i) * time.Millisecond)
+				}
+				if timer.Stop() {
+					atomic.StoreInt32(&stopped, 1)
+				}
+				ticker := clock.NewTicker(time.Millisecond)
+				ticker.Stop()
+				clock.PendingTimers()
+			}
+		}(i)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			assert.Zero(t, atomic.LoadInt32(&postStop))
+			return
+		default:
+			clock.Forward(time.Millisecond)
+		}
+	}
+}
+
+func TestMock_SameDueTimeOrder(t *testing.T) {
+	clock := NewMock()
+	var order []int
+	for i := 0; i < 20; i++ {
+		i := i
+		clock.AfterFunc(time.Second, func() { order = append(order, i) })
+	}
+	// A timer that was added later but is due earlier still fires first
+	clock.AfterFunc(time.Millisecond, func() { order = append(order, -1) })
+
+	clock.Forward(time.Second)
+	expected := []int{-1}
+	for i := 0; i < 20; i++ {
+		expected = append(expected, i)
+	}
+	assert.Equal(t, expected, order)
+}
+
+func TestMock_BeforeFire(t *testing.T) {
+	clock := NewMock()
+	type fire struct {
+		e  Executer
+		at time.Duration
+	}
+	var fires []fire
+	clock.BeforeFire(func(e Executer, at time.Time) {
+		// The hook may use the Mock
+		clock.PendingTimers()
+		fires = append(fires, fire{e, at.Sub(time.Unix(0, 0))})
+	})
+	ticker := clock.NewTicker(time.Minute)
+	late := clock.AfterFunc(90*time.Second, func() {})
+	early := clock.NewTimer(30 * time.Second)
+
+	clock.Forward(2 * time.Minute)
+	assert.Equal(t, []fire{
+		{early.(Executer), 30 * time.Second},
+		{ticker.(Executer), time.Minute},
+		{late.(Executer), 90 * time.Second},
+		{ticker.(Executer), 2 * time.Minute},
+	}, fires)
+
+	clock.BeforeFire(nil)
+	clock.Forward(time.Minute)
+	assert.Len(t, fires, 4)
+}
+
+func TestMock_Schedule(t *testing.T) {
+	clock := NewMock()
+	var fired []int
+	timers := clock.Schedule(
+		TimerSpec{After: time.Second, Func: func() { fired = append(fired, 1) }},
+		TimerSpec{After: time.Minute},
+		TimerSpec{After: 2 * time.Second, Func: func() { fired = append(fired, 2) }},
+		TimerSpec{After: time.Hour, Func: func() { fired = append(fired, 3) }},
+		TimerSpec{After: 0},
+	)
+	assert.Len(t, timers, 5)
+	assert.Equal(t, 4, clock.Len())
+	// Channel timers with a non-positive duration fire immediately
+	assert.Equal(t, time.Unix(0, 0), <-timers[4].Chan())
+
+	clock.Forward(time.Minute)
+	assert.Equal(t, []int{1, 2}, fired)
+	assert.Equal(t, time.Unix(60, 0), <-timers[1].Chan())
+	assert.True(t, timers[3].Stop())
+	clock.Forward(time.Hour)
+	assert.Equal(t, []int{1, 2}, fired)
+}
+
+func TestMock_ScheduleNonPositive(t *testing.T) {
+	clock := NewMock()
+	fired := make(chan struct{})
+	timers := clock.Schedule(
+		TimerSpec{After: -time.Second},
+		TimerSpec{After: 0, Func: func() { close(fired) }},
+	)
+
+	// Both fire without forwarding the internal time, like NewTimer(0) and AfterFunc(0)
+	assert.Equal(t, time.Unix(0, 0), <-timers[0].Chan())
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("callback did not run")
+	}
+	assert.Zero(t, clock.Len())
+}
+
+func TestMock_Sync(t *testing.T) {
+	trace := []time.Time{time.Unix(10, 0), time.Unix(30, 0), time.Unix(20, 0), time.Unix(70, 0)}
+	i := 0
+	clock := NewMock(WithTimeSource(func() time.Time {
+		t := trace[i]
+		i++
+		return t
+	}))
+	var fired []time.Time
+	clock.AfterFunc(15*time.Second, func() { fired = append(fired, clock.Now()) })
+	clock.AfterFunc(time.Minute, func() { fired = append(fired, clock.Now()) })
+
+	clock.Sync()
+	assert.Equal(t, time.Unix(10, 0), clock.Now())
+	assert.Empty(t, fired)
+	clock.Sync()
+	assert.Equal(t, []time.Time{time.Unix(30, 0)}, fired)
+	// Times before the internal time are ignored
+	clock.Sync()
+	assert.Equal(t, time.Unix(30, 0), clock.Now())
+	clock.Sync()
+	assert.Equal(t, []time.Time{time.Unix(30, 0), time.Unix(70, 0)}, fired)
+}
+
+func TestMock_SyncWithoutSource(t *testing.T) {
+	assert.Panics(t, func() { NewMock().Sync() })
+}
+
+func TestMock_ForwardNegative(t *testing.T) {
+	clock := NewMock()
+	assert.Panics(t, func() { clock.Forward(-time.Second) })
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+}
+
+func TestMock_WithYield(t *testing.T) {
+	var yields int32
+	clock := NewMock(WithYield(func() { atomic.AddInt32(&yields, 1) }))
+	clock.Forward(time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&yields))
+
+	// Every tick yields as well
+	clock.NewBufferedTicker(time.Second, 3)
+	clock.Forward(3 * time.Second)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&yields))
+}
+
+func TestMock_MaxForward(t *testing.T) {
+	clock := NewMock(WithMaxForward(time.Hour))
+	assert.NoError(t, clock.TryForward(time.Hour))
+	assert.Equal(t, time.Unix(3600, 0), clock.Now())
+
+	err := clock.TryForward(25 * time.Hour)
+	assert.EqualError(t, err, "clock: duration 25h0m0s passed to Forward exceeds the limit of 1h0m0s")
+	assert.Error(t, clock.TryForward(-time.Second))
+	assert.Equal(t, time.Unix(3600, 0), clock.Now())
+	assert.PanicsWithValue(t, "clock: duration 25h0m0s passed to Forward exceeds the limit of 1h0m0s", func() {
+		clock.Forward(25 * time.Hour)
+	})
+
+	// Without a limit, anything goes
+	assert.NoError(t, NewMock().TryForward(1000*time.Hour))
+}
+
+func TestMock_DriftRate(t *testing.T) {
+	// 1000 ppm makes the Mock run 0.1% fast
+	clock := NewMock(WithDriftRate(1000))
+	for i := 0; i < 100; i++ {
+		clock.Forward(30 * time.Second)
+	}
+	assert.Equal(t, 3003*time.Second, clock.ElapsedSinceStart())
+
+	// Timers fire early in terms of requested durations
+	var fired int32
+	clock.AfterFunc(time.Second, func() { atomic.AddInt32(&fired, 1) })
+	clock.Forward(999 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&fired))
+	clock.Forward(time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fired))
+
+	slow := NewMock(WithDriftRate(-500))
+	for i := 0; i < 7; i++ {
+		slow.Forward(10*time.Minute + time.Nanosecond)
+	}
+	assert.Equal(t, 70*time.Minute+7*time.Nanosecond-2100*time.Millisecond, slow.ElapsedSinceStart())
+}
+
+func TestMock_SetBackwards(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(100, 0))
+	var fired int32
+	clock.AfterFunc(time.Second, func() { atomic.AddInt32(&fired, 1) })
+	ticker := clock.NewTicker(time.Minute)
+
+	clock.Set(time.Unix(50, 0))
+	assert.Zero(t, atomic.LoadInt32(&fired))
+	assert.Empty(t, ticker.Chan())
+	assert.Equal(t, time.Unix(50, 0), clock.Now())
+
+	// Timers and tickers keep their next execution time
+	clock.Forward(51 * time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fired))
+	clock.Forward(59 * time.Second)
+	assert.Equal(t, time.Unix(160, 0), <-ticker.Chan())
+}
+
+func TestMock_FireDueWithin(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(100, 0))
+	var fired []time.Duration
+	for _, d := range []time.Duration{time.Nanosecond, time.Microsecond, time.Millisecond, time.Second} {
+		d := d
+		clock.AfterFunc(d, func() { fired = append(fired, d) })
+	}
+	now := clock.Now()
+
+	assert.Equal(t, 2, clock.FireDueWithin(time.Microsecond))
+	assert.Equal(t, []time.Duration{time.Nanosecond, time.Microsecond}, fired)
+	assert.Equal(t, now, clock.Now())
+	assert.Equal(t, 0, clock.FireDueWithin(time.Microsecond))
+	assert.Equal(t, 1, clock.FireDueWithin(-time.Millisecond))
+	assert.Equal(t, 1, clock.Len())
+}
+
+func TestMock_RemoveTimer(t *testing.T) {
+	clock := NewMock()
+	var timers []Timer
+	for i := 0; i < 10; i++ {
+		timers = append(timers, clock.NewTimer(time.Duration(i+1)*time.Second))
+	}
+	// Stop every other timer, the remaining ones must still fire
+	for i := 0; i < 10; i += 2 {
+		assert.True(t, timers[i].Stop())
+	}
+	assert.Equal(t, 5, clock.Len())
+	clock.Forward(10 * time.Second)
+	assert.Zero(t, clock.Len())
+	for i := 1; i < 10; i += 2 {
+		assert.Len(t, timers[i].Chan(), 1)
+	}
+	for i := 0; i < 10; i++ {
+		assert.False(t, timers[i].Stop())
+	}
+}
+
+func BenchmarkMock_Churn(b *testing.B) {
+	clock := NewMock()
+	for i := 0; i < 10000; i++ {
+		clock.NewTimer(time.Hour)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.NewTimer(time.Minute).Stop()
+	}
+}
+
+func BenchmarkMock_Now(b *testing.B) {
+	clock := NewMock()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			clock.Now()
+		}
+	})
+}
+
+// BenchmarkMock_NowLocked reads the internal time the way Now did before it became lock-free, as a baseline for
+// BenchmarkMock_Now.
+func BenchmarkMock_NowLocked(b *testing.B) {
+	clock := NewMock()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			clock.mu.RLock()
+			_ = clock.now
+			clock.mu.RUnlock()
+		}
+	})
+}
+
+func TestMock_ResetSequence(t *testing.T) {
+	clock := NewMock()
+	for i := 0; i < 5; i++ {
+		clock.NewTimer(time.Second).Stop()
+	}
+	clock.ResetSequence()
+	assert.Equal(t, uint64(1), clock.NewTimer(time.Second).(*fakeTimer).seq)
+
+	// Pending timers keep their order
+	clock = NewMock()
+	var order []int
+	for i := 0; i < 3; i++ {
+		i := i
+		clock.NewTimer(time.Hour).Stop()
+		clock.AfterFunc(time.Second, func() { order = append(order, i) })
+	}
+	clock.ResetSequence()
+	timer := clock.AfterFunc(time.Second, func() { order = append(order, 3) })
+	assert.Equal(t, uint64(4), timer.(*fakeTimer).seq)
+	clock.Forward(time.Second)
+	assert.Equal(t, []int{0, 1, 2, 3}, order)
+}
+
+func TestMock_ForwardUntil(t *testing.T) {
+	clock := NewMock()
+	var done int32
+	// A sequence of callbacks that converges after 35 seconds
+	for i := 1; i <= 5; i++ {
+		clock.AfterFunc(time.Duration(i)*7*time.Second, func() { atomic.AddInt32(&done, 1) })
+	}
+	converged := func() bool { return atomic.LoadInt32(&done) == 5 }
+
+	now, ok := clock.ForwardUntil(10*time.Second, converged, time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(40, 0), now)
+	assert.Equal(t, now, clock.Now())
+
+	// The predicate is satisfied already, nothing is forwarded
+	now, ok = clock.ForwardUntil(10*time.Second, converged, time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(40, 0), now)
+}
+
+func TestMock_ForwardUntilMax(t *testing.T) {
+	clock := NewMock()
+	calls := 0
+	now, ok := clock.ForwardUntil(time.Minute, func() bool { calls++; return false }, 150*time.Second)
+	assert.False(t, ok)
+	assert.Equal(t, time.Unix(150, 0), now)
+	assert.Equal(t, 4, calls)
+	assert.Panics(t, func() { clock.ForwardUntil(0, func() bool { return false }, time.Minute) })
+}
+
+func TestMock_ForwardTo(t *testing.T) {
+	clock := NewMock()
+	var fired []time.Time
+	for _, d := range []time.Duration{time.Second, time.Minute, time.Hour} {
+		clock.AfterFunc(d, func() { fired = append(fired, clock.Now()) })
+	}
+
+	assert.NoError(t, clock.ForwardTo(time.Unix(60, 0)))
+	assert.Equal(t, time.Unix(60, 0), clock.Now())
+	assert.Len(t, fired, 2)
+
+	// Forwarding to the current instant is a no-op
+	assert.NoError(t, clock.ForwardTo(time.Unix(60, 0)))
+	assert.Equal(t, time.Unix(60, 0), clock.Now())
+	assert.Len(t, fired, 2)
+
+	assert.Error(t, clock.ForwardTo(time.Unix(59, 0)))
+	assert.Equal(t, time.Unix(60, 0), clock.Now())
+	assert.Len(t, fired, 2)
+}
+
+func TestMock_AfterAny(t *testing.T) {
+	clock := NewMock()
+	ch := clock.AfterAny(time.Hour, time.Minute, 2*time.Minute)
+	clock.Forward(59 * time.Second)
+	assert.Empty(t, ch)
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(60, 0), <-ch)
+	// The longer durations don't fire anymore
+	clock.Forward(time.Hour)
+	assert.Empty(t, ch)
+	assert.Zero(t, clock.Len())
+
+	ch = clock.AfterAny()
+	clock.Forward(time.Hour)
+	assert.Empty(t, ch)
+}
+
+func TestMock_AfterAll(t *testing.T) {
+	clock := NewMock()
+	ch := clock.AfterAll(time.Minute, time.Hour, 2*time.Minute)
+	clock.Forward(time.Hour - time.Second)
+	assert.Empty(t, ch)
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(3600, 0), <-ch)
+
+	assert.Equal(t, time.Unix(3600, 0), <-clock.AfterAll())
+}
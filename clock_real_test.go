@@ -1,6 +1,8 @@
 package clock
 
 import (
+	"context"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -74,6 +76,16 @@ func TestClock_Sleep(t *testing.T) {
 	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
 }
 
+func TestClock_SleepContext(t *testing.T) {
+	assert.NoError(t, New().SleepContext(context.Background(), 10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Equal(t, context.DeadlineExceeded, New().SleepContext(ctx, time.Minute))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
 func TestClock_After(t *testing.T) {
 	var count int32
 	go incUponReceive(New().After(25*time.Millisecond), &count)
@@ -95,9 +107,205 @@ func TestClock_NewTimer(t *testing.T) {
 	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
 }
 
+func TestClock_NewTimerReset(t *testing.T) {
+	timer, reset := New().NewTimerReset(5 * time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	// The expired value is drained, only the reset fire is delivered
+	start := time.Now()
+	reset(20 * time.Millisecond)
+	fired := <-timer.Chan()
+	assert.True(t, fired.Sub(start) >= 20*time.Millisecond)
+}
+
 func TestClock_NewTicker(t *testing.T) {
 	var count int32
 	go incUponReceive(New().NewTicker(25*time.Millisecond).Chan(), &count)
 	time.Sleep(120 * time.Millisecond)
 	assert.Equal(t, int32(4), atomic.LoadInt32(&count))
 }
+
+func TestClock_NewTickerNonPositive(t *testing.T) {
+	assert.Panics(t, func() { New().NewTicker(0) })
+	assert.Panics(t, func() { New().NewTicker(-time.Second) })
+}
+
+func TestClock_NewTickerContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := New().NewTickerContext(ctx, 5*time.Millisecond)
+	<-ticker.Chan()
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	// Drain a tick that was sent before the ticker was stopped
+	select {
+	case <-ticker.Chan():
+	default:
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, ticker.Chan())
+}
+
+func TestClock_NewTickerImmediate(t *testing.T) {
+	start := time.Now()
+	ticker := New().NewTickerImmediate(20 * time.Millisecond)
+	defer ticker.Stop()
+	assert.Len(t, ticker.Chan(), 1)
+	<-ticker.Chan()
+	second := <-ticker.Chan()
+	assert.True(t, second.Sub(start) >= 20*time.Millisecond)
+}
+
+func TestClock_TryNewTicker(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		ticker, err := New().TryNewTicker(d)
+		assert.Nil(t, ticker)
+		assert.Equal(t, ErrNonPositiveInterval, err)
+	}
+	ticker, err := New().TryNewTicker(time.Millisecond)
+	assert.NoError(t, err)
+	<-ticker.Chan()
+	ticker.Stop()
+}
+
+func TestClock_Jitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := New().Jitter(time.Second, 0.5)
+		assert.True(t, d >= time.Second && d < 1500*time.Millisecond, "jitter %v out of range", d)
+	}
+	assert.Equal(t, time.Second, New().Jitter(time.Second, -1))
+}
+
+func TestClock_NewTimerCapped(t *testing.T) {
+	start := time.Now()
+	timer := New().NewTimerCapped(time.Hour, start.Add(10*time.Millisecond))
+	select {
+	case <-timer.Chan():
+		assert.True(t, time.Since(start) >= 10*time.Millisecond)
+	case <-time.After(time.Second):
+		t.Fatal("timer did not fire at the cap")
+	}
+}
+
+func TestClock_Every(t *testing.T) {
+	calls := make(chan struct{}, 10)
+	cancel := New().Every(time.Millisecond, func() { calls <- struct{}{} })
+	for i := 0; i < 3; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("function was not called")
+		}
+	}
+	cancel()
+	cancel()
+	// Drain a call that might have been in progress
+	time.Sleep(10 * time.Millisecond)
+	for len(calls) > 0 {
+		<-calls
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.Empty(t, calls)
+}
+
+func TestClock_WaitTimeout(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	assert.False(t, New().WaitTimeout(&wg, time.Millisecond))
+	go wg.Done()
+	assert.True(t, New().WaitTimeout(&wg, time.Minute))
+}
+
+func TestRealWithNow(t *testing.T) {
+	fixed := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewRealWithNow(func() time.Time { return fixed })
+	timer := c.NewTimer(10 * time.Millisecond)
+	assert.Equal(t, fixed, c.Now())
+	assert.Equal(t, fixed.Unix(), c.NowUnix())
+	assert.Equal(t, fixed.UnixMilli(), c.NowUnixMilli())
+	assert.Equal(t, fixed.UnixNano(), c.NowUnixNano())
+	assert.Equal(t, "2019-01-01T00:00:00Z", c.NowString(time.RFC3339))
+	assert.Equal(t, time.Hour, c.Since(fixed.Add(-time.Hour)))
+	assert.Equal(t, time.Hour, c.Until(fixed.Add(time.Hour)))
+	// The real timer fires although the reported time doesn't move
+	select {
+	case <-timer.Chan():
+	case <-time.After(time.Second):
+		t.Fatal("timer didn't fire")
+	}
+	assert.Equal(t, fixed, c.Now())
+}
+
+func TestOffset_Now(t *testing.T) {
+	c := NewOffset(time.Hour)
+	diff := c.Now().Sub(time.Now())
+	assert.InDelta(t, float64(time.Hour), float64(diff), float64(time.Second))
+
+	since := time.Now()
+	assert.InDelta(t, float64(time.Hour), float64(c.Since(since)), float64(time.Second))
+	assert.InDelta(t, float64(-time.Hour), float64(c.Until(since)), float64(time.Second))
+}
+
+func TestOffset_Skew(t *testing.T) {
+	a := NewOffset(-time.Minute)
+	b := NewOffset(time.Minute)
+	diff := b.Now().Sub(a.Now())
+	assert.InDelta(t, float64(2*time.Minute), float64(diff), float64(time.Second))
+}
+
+func TestOffset_NewTimer(t *testing.T) {
+	var count int32
+	c := NewOffset(time.Hour)
+	go incUponReceive(c.NewTimer(50*time.Millisecond).Chan(), &count)
+	time.Sleep(25 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestClock_SleepUntilNext(t *testing.T) {
+	c := New()
+	start := time.Now()
+	next := start.Add(untilNext(start, 50*time.Millisecond))
+	c.SleepUntilNext(50 * time.Millisecond)
+	// The scheduler may wake the goroutine up late, so the upper bound is generous. untilNext is tested separately.
+	end := time.Now()
+	assert.False(t, end.Before(next), "woke up at %v before %v", end, next)
+	assert.True(t, end.Sub(next) < time.Second, "woke up at %v, long after %v", end, next)
+}
+
+func TestClock_NewTickerChan(t *testing.T) {
+	ticker, ch := New().NewTickerChan(time.Second)
+	defer ticker.Stop()
+	assert.Equal(t, ticker.Chan(), ch)
+}
+
+func TestClock_NewTimerAt(t *testing.T) {
+	var count int32
+	go incUponReceive(New().NewTimerAt(time.Now().Add(25*time.Millisecond)).Chan(), &count)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestClock_NewTickerAt(t *testing.T) {
+	start := time.Now().Add(50 * time.Millisecond)
+	ticker := New().NewTickerAt(start, 30*time.Millisecond)
+	defer ticker.Stop()
+	// Only lower bounds are checked, since the scheduler may delay ticks. The exact timing is tested on the Mock.
+	first := <-ticker.Chan()
+	assert.False(t, first.Before(start), "first tick at %v before %v", first, start)
+	second := <-ticker.Chan()
+	assert.True(t, second.Sub(first) >= 30*time.Millisecond, "second tick %v after the first", second.Sub(first))
+}
+
+func TestClock_AfterAnyAll(t *testing.T) {
+	var anyCount, allCount int32
+	go incUponReceive(New().AfterAny(50*time.Millisecond, 10*time.Millisecond), &anyCount)
+	go incUponReceive(New().AfterAll(50*time.Millisecond, 10*time.Millisecond), &allCount)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&anyCount))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&allCount))
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&anyCount))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&allCount))
+}
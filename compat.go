@@ -0,0 +1,36 @@
+package clock
+
+import "time"
+
+// The methods in this file mirror the API of the Mock in github.com/benbjohnson/clock to ease migrating tests from
+// that package. They are only aliases: new code should use the native names they refer to.
+
+// Add moves the internal time forward by d. It is an alias of Forward for compatibility with benbjohnson/clock.
+func (m *Mock) Add(d time.Duration) {
+	m.Forward(d)
+}
+
+// Timer creates a new Timer. It is an alias of NewTimer for compatibility with benbjohnson/clock. The channel of the
+// Timer is returned by its Chan method, there is no field C.
+func (m *Mock) Timer(d time.Duration) Timer {
+	return m.NewTimer(d)
+}
+
+// Ticker creates a new Ticker. It is an alias of NewTicker for compatibility with benbjohnson/clock. The channel of
+// the Ticker is returned by its Chan method, there is no field C.
+func (m *Mock) Ticker(d time.Duration) Ticker {
+	return m.NewTicker(d)
+}
+
+// Tick returns the channel of a new Ticker, like time.Tick. The Ticker can't be stopped. It exists for compatibility
+// with benbjohnson/clock.
+func (m *Mock) Tick(d time.Duration) <-chan time.Time {
+	return m.NewTicker(d).Chan()
+}
+
+// WaitForAllTimers moves the internal time forward until all Timers have fired and returns the new internal time.
+// It is an alias of RunUntilDone for compatibility with benbjohnson/clock.
+func (m *Mock) WaitForAllTimers() time.Time {
+	m.RunUntilDone()
+	return m.Now()
+}
@@ -0,0 +1,29 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_Compat(t *testing.T) {
+	native, compat := NewMock(), NewMock()
+	nativeTimer, compatTimer := native.NewTimer(2*time.Second), compat.Timer(2*time.Second)
+	nativeTicker, compatTicker := native.NewTicker(time.Second), compat.Ticker(time.Second)
+	tick := compat.Tick(time.Second)
+
+	native.Forward(time.Second)
+	compat.Add(time.Second)
+	assert.Equal(t, native.Now(), compat.Now())
+	assert.Equal(t, <-nativeTicker.Chan(), <-compatTicker.Chan())
+	assert.Equal(t, compat.Now(), <-tick)
+	assert.Empty(t, compatTimer.Chan())
+
+	native.RunUntilDone()
+	assert.Equal(t, native.Now(), compat.WaitForAllTimers())
+	assert.Equal(t, native.Now(), compat.Now())
+	assert.Equal(t, <-nativeTimer.Chan(), <-compatTimer.Chan())
+	// The Ticker behind Tick can't be stopped and is still pending
+	assert.Equal(t, native.Len()+1, compat.Len())
+}
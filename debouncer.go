@@ -0,0 +1,43 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer runs a function once a delay has passed without any new trigger. It is built on AfterFunc of a Clock, so
+// it can be tested by moving the time of a Mock forward.
+type Debouncer struct {
+	mu    sync.Mutex
+	clock Clock
+	delay time.Duration
+	fn    func()
+	timer Timer
+}
+
+// NewDebouncer returns a Debouncer that runs fn after delay has passed since the last call to Trigger.
+func NewDebouncer(c Clock, delay time.Duration, fn func()) *Debouncer {
+	return &Debouncer{clock: c, delay: delay, fn: fn}
+}
+
+// Trigger arms the Debouncer. If it is already armed, the delay starts over.
+func (d *Debouncer) Trigger() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer == nil {
+		d.timer = d.clock.AfterFunc(d.delay, d.fn)
+		return
+	}
+	d.timer.Reset(d.delay)
+}
+
+// Stop disarms the Debouncer. It returns true if the call prevented the function from running. A later call to
+// Trigger arms the Debouncer again.
+func (d *Debouncer) Stop() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer == nil {
+		return false
+	}
+	return d.timer.Stop()
+}
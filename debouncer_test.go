@@ -0,0 +1,61 @@
+package clock
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebouncer(t *testing.T) {
+	clock := NewMock()
+	count := 0
+	d := NewDebouncer(clock, time.Second, func() { count++ })
+
+	// Triggers within the delay run fn once after the quiet period
+	for i := 0; i < 5; i++ {
+		d.Trigger()
+		clock.Forward(900 * time.Millisecond)
+	}
+	assert.Equal(t, 0, count)
+	clock.Forward(100 * time.Millisecond)
+	assert.Equal(t, 1, count)
+	clock.Forward(time.Hour)
+	assert.Equal(t, 1, count)
+
+	// Triggers with gaps run fn every time
+	for i := 0; i < 3; i++ {
+		d.Trigger()
+		clock.Forward(2 * time.Second)
+	}
+	assert.Equal(t, 4, count)
+
+	d.Trigger()
+	assert.True(t, d.Stop())
+	clock.Forward(time.Hour)
+	assert.Equal(t, 4, count)
+	assert.False(t, NewDebouncer(clock, time.Second, func() {}).Stop())
+}
+
+func TestDebouncer_ZeroDelay(t *testing.T) {
+	clock := NewMock()
+	runs := make(chan struct{}, 2)
+	var count int32
+	var d *Debouncer
+	d = NewDebouncer(clock, 0, func() {
+		runs <- struct{}{}
+		if atomic.AddInt32(&count, 1) == 1 {
+			// Triggering from fn must not deadlock on the lock held by Trigger
+			d.Trigger()
+		}
+	})
+	d.Trigger()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatal("fn did not run")
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	defaultMu    sync.RWMutex
+	defaultClock = New()
+)
+
+// Default returns the package-level default Clock, which is a Clock based on the time package unless it has been
+// replaced with SetDefault. The package-level functions like Now and After delegate to it. This allows to migrate
+// large code bases gradually: code that can't be passed a Clock yet calls the package-level functions instead.
+func Default() Clock {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClock
+}
+
+// SetDefault replaces the package-level default Clock. Passing nil restores a Clock based on the time package.
+//
+// SetDefault is meant to be used in tests only, e.g. to install a Mock. Since the default Clock is shared by the whole
+// process, tests that replace it must not run in parallel with other tests that use it.
+func SetDefault(c Clock) {
+	if c == nil {
+		c = New()
+	}
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClock = c
+}
+
+// After calls After on the default Clock.
+func After(d time.Duration) <-chan time.Time { return Default().After(d) }
+
+// AfterAny calls AfterAny on the default Clock.
+func AfterAny(ds ...time.Duration) <-chan time.Time { return Default().AfterAny(ds...) }
+
+// AfterAll calls AfterAll on the default Clock.
+func AfterAll(ds ...time.Duration) <-chan time.Time { return Default().AfterAll(ds...) }
+
+// AfterFunc calls AfterFunc on the default Clock.
+func AfterFunc(d time.Duration, fn func()) Timer { return Default().AfterFunc(d, fn) }
+
+// Now calls Now on the default Clock.
+func Now() time.Time { return Default().Now() }
+
+// NowUnix calls NowUnix on the default Clock.
+func NowUnix() int64 { return Default().NowUnix() }
+
+// NowUnixMilli calls NowUnixMilli on the default Clock.
+func NowUnixMilli() int64 { return Default().NowUnixMilli() }
+
+// NowUnixNano calls NowUnixNano on the default Clock.
+func NowUnixNano() int64 { return Default().NowUnixNano() }
+
+// NowString calls NowString on the default Clock.
+func NowString(layout string) string { return Default().NowString(layout) }
+
+// Since calls Since on the default Clock.
+func Since(t time.Time) time.Duration { return Default().Since(t) }
+
+// Until calls Until on the default Clock.
+func Until(t time.Time) time.Duration { return Default().Until(t) }
+
+// Sleep calls Sleep on the default Clock.
+func Sleep(d time.Duration) { Default().Sleep(d) }
+
+// SleepContext calls SleepContext on the default Clock.
+func SleepContext(ctx context.Context, d time.Duration) error { return Default().SleepContext(ctx, d) }
+
+// WaitTimeout calls WaitTimeout on the default Clock.
+func WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool { return Default().WaitTimeout(wg, d) }
+
+// Every calls Every on the default Clock.
+func Every(d time.Duration, fn func()) (cancel func()) { return Default().Every(d, fn) }
+
+// NewTicker calls NewTicker on the default Clock.
+func NewTicker(d time.Duration) Ticker { return Default().NewTicker(d) }
+
+// TryNewTicker calls TryNewTicker on the default Clock.
+func TryNewTicker(d time.Duration) (Ticker, error) { return Default().TryNewTicker(d) }
+
+// NewTickerContext calls NewTickerContext on the default Clock.
+func NewTickerContext(ctx context.Context, d time.Duration) Ticker {
+	return Default().NewTickerContext(ctx, d)
+}
+
+// NewTickerChan calls NewTickerChan on the default Clock.
+func NewTickerChan(d time.Duration) (Ticker, <-chan time.Time) { return Default().NewTickerChan(d) }
+
+// NewTickerAt calls NewTickerAt on the default Clock.
+func NewTickerAt(start time.Time, d time.Duration) Ticker { return Default().NewTickerAt(start, d) }
+
+// NewTickerImmediate calls NewTickerImmediate on the default Clock.
+func NewTickerImmediate(d time.Duration) Ticker { return Default().NewTickerImmediate(d) }
+
+// NewTimer calls NewTimer on the default Clock.
+func NewTimer(d time.Duration) Timer { return Default().NewTimer(d) }
+
+// NewTimerReset calls NewTimerReset on the default Clock.
+func NewTimerReset(d time.Duration) (Timer, func(time.Duration)) { return Default().NewTimerReset(d) }
+
+// NewTimerAt calls NewTimerAt on the default Clock.
+func NewTimerAt(t time.Time) Timer { return Default().NewTimerAt(t) }
+
+// NewTimerCapped calls NewTimerCapped on the default Clock.
+func NewTimerCapped(d time.Duration, notAfter time.Time) Timer {
+	return Default().NewTimerCapped(d, notAfter)
+}
+
+// SleepUntilNext calls SleepUntilNext on the default Clock.
+func SleepUntilNext(period time.Duration) { Default().SleepUntilNext(period) }
+
+// Jitter calls Jitter on the default Clock.
+func Jitter(base time.Duration, factor float64) time.Duration { return Default().Jitter(base, factor) }
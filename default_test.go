@@ -0,0 +1,33 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	assert.IsType(t, &clock{}, Default())
+
+	mock := NewMock()
+	SetDefault(mock)
+	defer SetDefault(nil)
+	assert.Equal(t, mock, Default())
+
+	start := Now()
+	assert.Equal(t, mock.Now(), start)
+	timer := NewTimer(time.Minute)
+	fired := false
+	AfterFunc(time.Minute, func() { fired = true })
+
+	mock.Forward(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), Now())
+	assert.Equal(t, time.Minute, Since(start))
+	assert.Equal(t, -time.Minute, Until(start))
+	assert.Equal(t, start.Add(time.Minute), <-timer.Chan())
+	assert.True(t, fired)
+
+	SetDefault(nil)
+	assert.IsType(t, &clock{}, Default())
+}
@@ -0,0 +1,92 @@
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType describes what happened in an Event recorded by the event log of a Mock.
+type EventType int
+
+const (
+	// TimerCreated is recorded when a Timer is created
+	TimerCreated EventType = iota
+	// TimerFired is recorded when a Timer fires
+	TimerFired
+	// TimerStopped is recorded when a call to Stop stops a Timer that was active
+	TimerStopped
+	// TimerReset is recorded when a Timer is reset
+	TimerReset
+	// TickerTicked is recorded when a Ticker ticks
+	TickerTicked
+)
+
+// String returns the name of the event type.
+func (e EventType) String() string {
+	switch e {
+	case TimerCreated:
+		return "TimerCreated"
+	case TimerFired:
+		return "TimerFired"
+	case TimerStopped:
+		return "TimerStopped"
+	case TimerReset:
+		return "TimerReset"
+	case TickerTicked:
+		return "TickerTicked"
+	}
+	return fmt.Sprintf("EventType(%d)", int(e))
+}
+
+// Event is an entry of the event log of a Mock.
+type Event struct {
+	// Type tells what happened
+	Type EventType
+	// At is the internal time of the event. For TimerFired and TickerTicked, it is the time the Timer or Ticker was
+	// due at.
+	At time.Time
+	// Label is the label of the Timer or Ticker, if any
+	Label string
+}
+
+// loggedEvent is an Event whose label is resolved when the log is read, since labels are set after creation.
+type loggedEvent struct {
+	Event
+	src Labeled
+}
+
+// WithEventLog makes the Mock record a chronological log of what happens to its Timers and Tickers. The log can be
+// read with Events and helps debugging flaky schedules. Without this option, nothing is recorded.
+func WithEventLog() Option {
+	return func(m *Mock) { m.eventLog = true }
+}
+
+// Events returns the events recorded since the Mock was created, oldest first. It returns nil if the event log has
+// not been enabled with WithEventLog.
+func (m *Mock) Events() []Event {
+	m.traceMu.Lock()
+	logged := make([]loggedEvent, len(m.events))
+	copy(logged, m.events)
+	m.traceMu.Unlock()
+	if len(logged) == 0 {
+		return nil
+	}
+
+	// Labels are read without holding traceMu, since events are logged while holding the lock of a Timer or Ticker
+	events := make([]Event, len(logged))
+	for i, e := range logged {
+		events[i] = e.Event
+		events[i].Label = e.src.Label()
+	}
+	return events
+}
+
+// logEvent appends an event to the event log, if it is enabled.
+func (m *Mock) logEvent(typ EventType, at time.Time, src Labeled) {
+	if !m.eventLog {
+		return
+	}
+	m.traceMu.Lock()
+	defer m.traceMu.Unlock()
+	m.events = append(m.events, loggedEvent{Event: Event{Type: typ, At: at}, src: src})
+}
@@ -0,0 +1,42 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_Events(t *testing.T) {
+	clock := NewMock(WithEventLog())
+	timer := clock.NewLabeledTimer(3*time.Second, "timeout")
+	ticker := clock.NewLabeledTicker(2*time.Second, "tick")
+	clock.Forward(2 * time.Second)
+	timer.Reset(5 * time.Second)
+	clock.Forward(3 * time.Second)
+	ticker.Stop()
+	assert.True(t, timer.Stop())
+	assert.False(t, timer.Stop())
+	clock.AfterFunc(time.Second, func() {})
+	clock.Forward(time.Second)
+
+	at := func(s int64) time.Time { return time.Unix(s, 0) }
+	assert.Equal(t, []Event{
+		{Type: TimerCreated, At: at(0), Label: "timeout"},
+		{Type: TickerTicked, At: at(2), Label: "tick"},
+		{Type: TimerReset, At: at(2), Label: "timeout"},
+		{Type: TickerTicked, At: at(4), Label: "tick"},
+		{Type: TimerStopped, At: at(5), Label: "timeout"},
+		{Type: TimerCreated, At: at(5)},
+		{Type: TimerFired, At: at(6)},
+	}, clock.Events())
+	assert.Equal(t, "TickerTicked", TickerTicked.String())
+}
+
+func TestMock_EventsDisabled(t *testing.T) {
+	clock := NewMock()
+	clock.NewTimer(time.Second)
+	clock.Forward(time.Second)
+	assert.Nil(t, clock.Events())
+	assert.Nil(t, clock.events)
+}
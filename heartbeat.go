@@ -0,0 +1,108 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Heartbeater implements both sides of a heartbeat protocol as used for leader election: the leader sends a
+// heartbeat every interval, while followers declare the leader lost if they don't receive a heartbeat within the
+// timeout. The cadence is driven by a Ticker and the failure detection by a resettable Timer of the Clock, so both
+// can be controlled with a Mock.
+type Heartbeater struct {
+	mu       sync.Mutex
+	clock    Clock
+	interval time.Duration
+	timeout  time.Duration
+	ticker   Ticker
+	timer    Timer
+	stop     chan struct{}
+	lost     chan struct{}
+	stopped  bool
+}
+
+// NewHeartbeater returns a Heartbeater that sends heartbeats every interval and declares the leader lost if no
+// heartbeat is received within timeout. The timeout starts immediately.
+func NewHeartbeater(c Clock, interval, timeout time.Duration) *Heartbeater {
+	h := &Heartbeater{
+		clock:    c,
+		interval: interval,
+		timeout:  timeout,
+		stop:     make(chan struct{}),
+		lost:     make(chan struct{}),
+	}
+	h.timer = c.AfterFunc(timeout, h.markLost)
+	return h
+}
+
+// Start starts sending heartbeats by calling send every interval in a new goroutine.
+// Start must be called at most once.
+func (h *Heartbeater) Start(send func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return
+	}
+	h.ticker = h.clock.NewTicker(h.interval)
+	go func(ch <-chan time.Time) {
+		for {
+			select {
+			case <-ch:
+				send()
+			case <-h.stop:
+				return
+			}
+		}
+	}(h.ticker.Chan())
+}
+
+// Beat records a received heartbeat and restarts the timeout. Once the leader has been declared lost, Beat has no
+// effect.
+func (h *Heartbeater) Beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped || h.isLost() {
+		return
+	}
+	h.timer.Reset(h.timeout)
+}
+
+// Lost returns a channel that is closed when no heartbeat has been received within the timeout.
+func (h *Heartbeater) Lost() <-chan struct{} {
+	return h.lost
+}
+
+// Stop stops sending heartbeats and the failure detection.
+func (h *Heartbeater) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return
+	}
+	h.stopped = true
+	close(h.stop)
+	if h.ticker != nil {
+		h.ticker.Stop()
+	}
+	h.timer.Stop()
+}
+
+// markLost declares the leader lost.
+func (h *Heartbeater) markLost() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped || h.isLost() {
+		return
+	}
+	close(h.lost)
+}
+
+// isLost reports whether the leader has been declared lost. The caller must hold h.mu.
+func (h *Heartbeater) isLost() bool {
+	select {
+	case <-h.lost:
+		return true
+	default:
+		return false
+	}
+}
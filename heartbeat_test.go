@@ -0,0 +1,60 @@
+package clock
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeater_Steady(t *testing.T) {
+	clock := NewMock()
+	h := NewHeartbeater(clock, time.Second, 3*time.Second)
+	defer h.Stop()
+	var sent int32
+	h.Start(func() {
+		atomic.AddInt32(&sent, 1)
+		h.Beat()
+	})
+	sched()
+
+	for i := 0; i < 10; i++ {
+		clock.Forward(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, int32(10), atomic.LoadInt32(&sent))
+	assert.Empty(t, h.Lost())
+}
+
+func TestHeartbeater_Lost(t *testing.T) {
+	clock := NewMock()
+	h := NewHeartbeater(clock, time.Second, 3*time.Second)
+	defer h.Stop()
+	var sent int32
+	h.Start(func() {
+		// The leader dies after two heartbeats
+		if atomic.AddInt32(&sent, 1) <= 2 {
+			h.Beat()
+		}
+	})
+	sched()
+
+	for i := 0; i < 4; i++ {
+		clock.Forward(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-h.Lost():
+		t.Fatal("leader lost before the timeout")
+	default:
+	}
+
+	// The last heartbeat was received after 2 seconds, the timeout expires after 5 seconds
+	clock.Forward(time.Second)
+	select {
+	case <-h.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("leader not lost after the timeout")
+	}
+}
@@ -0,0 +1,32 @@
+package clock
+
+import "time"
+
+// Hedger runs requests with a staggered backup to reduce tail latency. If the primary call doesn't complete within
+// the configured delay, a backup call is started and the result of whichever call finishes first is used.
+// The delay is measured with Clock.AfterFunc, so a Mock can decide deterministically when the backup is launched.
+type Hedger struct {
+	clock Clock
+	delay time.Duration
+}
+
+// NewHedger returns a Hedger that launches a backup call after delay.
+func NewHedger(c Clock, delay time.Duration) *Hedger {
+	return &Hedger{clock: c, delay: delay}
+}
+
+// Do runs primary and returns its result. If primary has not returned after the Hedger's delay, backup is started
+// in its own goroutine and Do returns the result of whichever call finishes first, even if that result is an error.
+// The call that loses the race is not cancelled, but its result is discarded.
+// If primary returns before the delay has elapsed, backup is never called.
+func (h *Hedger) Do(primary, backup func() error) error {
+	// Buffer both results so the losing call never blocks
+	results := make(chan error, 2)
+	go func() { results <- primary() }()
+	t := h.clock.AfterFunc(h.delay, func() {
+		go func() { results <- backup() }()
+	})
+	err := <-results
+	t.Stop()
+	return err
+}
@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedger_PrimaryWins(t *testing.T) {
+	clock := NewMock()
+	h := NewHedger(clock, time.Second)
+	var backups int32
+	errPrimary := errors.New("primary")
+
+	err := h.Do(
+		func() error { return errPrimary },
+		func() error { atomic.AddInt32(&backups, 1); return nil },
+	)
+	assert.Equal(t, errPrimary, err)
+
+	clock.Forward(time.Minute)
+	assert.Zero(t, atomic.LoadInt32(&backups))
+	assert.Zero(t, clock.Len())
+}
+
+func TestHedger_BackupWins(t *testing.T) {
+	clock := NewMock()
+	h := NewHedger(clock, time.Second)
+	var backups int32
+	errBackup := errors.New("backup")
+	release := make(chan struct{})
+	defer close(release)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- h.Do(
+			func() error { <-release; return nil },
+			func() error { atomic.AddInt32(&backups, 1); return errBackup },
+		)
+	}()
+	// Wait until Do has armed its timer, otherwise the Forward would miss it
+	for clock.Len() == 0 {
+		sched()
+	}
+
+	clock.Forward(time.Second - time.Millisecond)
+	assert.Zero(t, atomic.LoadInt32(&backups))
+	select {
+	case <-result:
+		t.Fatal("Do returned before the backup was launched")
+	default:
+	}
+
+	clock.Forward(time.Millisecond)
+	select {
+	case err := <-result:
+		assert.Equal(t, errBackup, err)
+	case <-time.After(time.Second):
+		t.Fatal("Do did not return")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&backups))
+}
@@ -0,0 +1,85 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter. It holds up to burst tokens and refills one token every rate. The tokens
+// are accounted with the Now of its Clock, so a Mock refills them deterministically when its time is moved forward.
+type Limiter struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   time.Duration
+	burst  int
+	tokens int
+	// last is the time up to which tokens have been refilled
+	last time.Time
+}
+
+// NewLimiter returns a Limiter that allows bursts of up to burst events and refills one token every rate. The bucket
+// starts full. NewLimiter panics if rate is not positive or burst is smaller than one.
+func NewLimiter(c Clock, rate time.Duration, burst int) *Limiter {
+	if rate <= 0 {
+		panic("clock: non-positive rate passed to NewLimiter")
+	}
+	if burst < 1 {
+		panic("clock: burst passed to NewLimiter must be at least one")
+	}
+	return &Limiter{clock: c, rate: rate, burst: burst, tokens: burst, last: c.Now()}
+}
+
+// Allow takes a token and returns true if one is available. Otherwise, it returns false.
+func (l *Limiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(l.clock.Now())
+	if l.tokens == 0 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// Wait blocks until a token is available and takes it. It returns the error of ctx if ctx is done before.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.mu.Lock()
+		now := l.clock.Now()
+		l.refill(now)
+		if l.tokens > 0 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		d := l.last.Add(l.rate).Sub(now)
+		l.mu.Unlock()
+
+		if err := l.clock.SleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// refill adds the tokens that have accumulated since the last refill. The caller must hold l.mu.
+func (l *Limiter) refill(now time.Time) {
+	if l.tokens >= l.burst {
+		l.last = now
+		return
+	}
+	n := now.Sub(l.last) / l.rate
+	if n <= 0 {
+		return
+	}
+	l.last = l.last.Add(n * l.rate)
+	if int64(n) >= int64(l.burst-l.tokens) {
+		l.tokens = l.burst
+		l.last = now
+		return
+	}
+	l.tokens += int(n)
+}
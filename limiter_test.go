@@ -0,0 +1,72 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	clock := NewMock()
+	l := NewLimiter(clock, time.Second, 2)
+
+	var got []bool
+	step := func(d time.Duration, n int) {
+		clock.Forward(d)
+		for i := 0; i < n; i++ {
+			got = append(got, l.Allow())
+		}
+	}
+	step(0, 3)
+	step(500*time.Millisecond, 1)
+	step(500*time.Millisecond, 2)
+	step(1500*time.Millisecond, 2)
+	// The remaining half of a token is kept
+	step(500*time.Millisecond, 1)
+	// No more than burst tokens accumulate
+	step(time.Hour, 3)
+	assert.Equal(t, []bool{
+		true, true, false,
+		false,
+		true, false,
+		true, false,
+		true,
+		true, true, false,
+	}, got)
+
+	assert.Panics(t, func() { NewLimiter(clock, 0, 1) })
+	assert.Panics(t, func() { NewLimiter(clock, time.Second, 0) })
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	clock := NewMock()
+	l := NewLimiter(clock, time.Second, 1)
+	assert.NoError(t, l.Wait(context.Background()))
+
+	done := make(chan error, 1)
+	go func() { done <- l.Wait(context.Background()) }()
+	for len(clock.PendingTimers()) == 0 {
+		sched()
+	}
+	clock.Forward(999 * time.Millisecond)
+	assert.Empty(t, done)
+	clock.Forward(time.Millisecond)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return")
+	}
+	assert.False(t, l.Allow())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- l.Wait(ctx) }()
+	for len(clock.PendingTimers()) == 0 {
+		sched()
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Zero(t, len(clock.PendingTimers()))
+}
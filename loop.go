@@ -0,0 +1,81 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Command is an instruction processed by the event loop started with StartLoop.
+type Command interface {
+	// apply executes the command on the Mock and reports whether the loop should stop
+	apply(m *Mock) bool
+}
+
+// forwardCommand forwards the Mock.
+type forwardCommand time.Duration
+
+func (c forwardCommand) apply(m *Mock) bool {
+	m.Forward(time.Duration(c))
+	return false
+}
+
+// setCommand sets the internal time of the Mock.
+type setCommand time.Time
+
+func (c setCommand) apply(m *Mock) bool {
+	m.Set(time.Time(c))
+	return false
+}
+
+// stopCommand stops the event loop.
+type stopCommand struct{}
+
+func (stopCommand) apply(*Mock) bool { return true }
+
+// ForwardCommand returns a Command that calls Forward with d.
+func ForwardCommand(d time.Duration) Command { return forwardCommand(d) }
+
+// SetCommand returns a Command that calls Set with t.
+func SetCommand(t time.Time) Command { return setCommand(t) }
+
+// StopCommand returns a Command that stops the event loop.
+func StopCommand() Command { return stopCommand{} }
+
+// StartLoop starts an event loop in a new goroutine that processes Commands sent to the returned channel one after
+// another. This way, the Mock can be driven from multiple goroutines, e.g. in an actor-style test harness, without
+// any additional locking.
+//
+// The loop stops when it receives a StopCommand or when the command channel is closed. The done channel is closed
+// once the loop has stopped, at which point all previously sent Commands have been processed. Nothing receives
+// Commands after that, so senders that might race with a StopCommand should select on done, or use
+// StartLoopWithStop.
+func (m *Mock) StartLoop() (commands chan<- Command, done <-chan struct{}) {
+	commands, done, _ = m.StartLoopWithStop()
+	return commands, done
+}
+
+// StartLoopWithStop behaves like StartLoop, but also returns a function that stops the loop. stop returns once the
+// loop has stopped and can be called any number of times, also after the loop has stopped for another reason.
+func (m *Mock) StartLoopWithStop() (commands chan<- Command, done <-chan struct{}, stop func()) {
+	cmds := make(chan Command)
+	quit := make(chan struct{})
+	d := make(chan struct{})
+	go func() {
+		defer close(d)
+		for {
+			select {
+			case c, ok := <-cmds:
+				if !ok || c.apply(m) {
+					return
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return cmds, d, func() {
+		once.Do(func() { close(quit) })
+		<-d
+	}
+}
@@ -0,0 +1,52 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_StartLoop(t *testing.T) {
+	clock := NewMock()
+	var fired []time.Time
+	clock.AfterFunc(time.Minute, func() { fired = append(fired, clock.Now()) })
+	clock.AfterFunc(time.Hour, func() { fired = append(fired, clock.Now()) })
+
+	commands, done := clock.StartLoop()
+	commands <- ForwardCommand(30 * time.Second)
+	commands <- ForwardCommand(30 * time.Second)
+	commands <- SetCommand(time.Unix(0, 0).Add(2 * time.Hour))
+	commands <- StopCommand()
+	<-done
+
+	assert.Equal(t, []time.Time{time.Unix(60, 0), time.Unix(7200, 0)}, fired)
+	assert.Equal(t, time.Unix(7200, 0), clock.Now())
+}
+
+func TestMock_StartLoopClose(t *testing.T) {
+	clock := NewMock()
+	commands, done := clock.StartLoop()
+	commands <- ForwardCommand(time.Second)
+	close(commands)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("loop did not stop")
+	}
+	assert.Equal(t, time.Unix(1, 0), clock.Now())
+}
+
+func TestMock_StartLoopStopTwice(t *testing.T) {
+	clock := NewMock()
+	commands, done, stop := clock.StartLoopWithStop()
+	commands <- ForwardCommand(time.Second)
+	stop()
+	stop()
+	assert.Equal(t, time.Unix(1, 0), clock.Now())
+	select {
+	case <-done:
+	default:
+		t.Fatal("loop did not stop")
+	}
+}
@@ -0,0 +1,136 @@
+package clock
+
+import "fmt"
+
+// Op is an operation performed on a Timer, as recorded by the operation trace of a Mock.
+type Op int
+
+const (
+	// OpCreate is recorded when a Timer is created
+	OpCreate Op = iota
+	// OpReset is recorded when a Timer is reset
+	OpReset
+	// OpStop is recorded when a Timer is stopped
+	OpStop
+	// OpExecute is recorded when a Timer fires
+	OpExecute
+)
+
+// String returns the name of the operation.
+func (o Op) String() string {
+	switch o {
+	case OpCreate:
+		return "Create"
+	case OpReset:
+		return "Reset"
+	case OpStop:
+		return "Stop"
+	case OpExecute:
+		return "Execute"
+	}
+	return fmt.Sprintf("Op(%d)", int(o))
+}
+
+// TestingT is the subset of testing.TB used to report failures.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// opRecord is a single recorded operation.
+type opRecord struct {
+	op Op
+	// undrained is set for resets of Timers whose channel still held a value
+	undrained bool
+}
+
+// EnableOpTrace makes the Mock record the operations performed on every Timer created afterwards. The trace can be
+// inspected with OpTrace and checked for common mistakes with AssertNoMisuse.
+func (m *Mock) EnableOpTrace() {
+	m.traceMu.Lock()
+	defer m.traceMu.Unlock()
+	if m.ops == nil {
+		m.ops = make(map[*fakeTimer][]opRecord)
+	}
+}
+
+// OpTrace returns the operations recorded for t in the order they were performed. It returns nil if t hasn't been
+// created by the Mock while the operation trace was enabled.
+func (m *Mock) OpTrace(t Timer) []Op {
+	f, ok := t.(*fakeTimer)
+	if !ok {
+		return nil
+	}
+	m.traceMu.Lock()
+	defer m.traceMu.Unlock()
+	var ops []Op
+	for _, r := range m.ops[f] {
+		ops = append(ops, r.op)
+	}
+	return ops
+}
+
+// AssertNoMisuse reports an error to t for every misuse of a Timer found in the operation trace. The following
+// patterns are reported:
+//   - Reset on a Timer that has fired while its value hasn't been received from the channel. With the time package,
+//     the stale value would be received after the Reset.
+//   - Stop on a Timer that has already been stopped without a Reset in between.
+//
+// It returns true if no misuse was found.
+func (m *Mock) AssertNoMisuse(t TestingT) bool {
+	type misuse struct {
+		f   *fakeTimer
+		i   int
+		msg string
+	}
+	var found []misuse
+	m.traceMu.Lock()
+	for i, f := range m.traced {
+		stopped := false
+		for _, r := range m.ops[f] {
+			switch r.op {
+			case OpReset:
+				if r.undrained {
+					found = append(found, misuse{f, i, "Reset after the timer fired without draining its channel"})
+				}
+				stopped = false
+			case OpStop:
+				if stopped {
+					found = append(found, misuse{f, i, "Stop called on a timer that has already been stopped"})
+				}
+				stopped = true
+			}
+		}
+	}
+	m.traceMu.Unlock()
+
+	// Labels are read without holding traceMu, since operations are recorded while holding the lock of a Timer
+	for _, u := range found {
+		t.Errorf("clock: %s: %s", traceName(u.f, u.i), u.msg)
+	}
+	return len(found) == 0
+}
+
+// record adds an operation to the trace of f, if the trace is enabled.
+func (m *Mock) record(f *fakeTimer, r opRecord) {
+	m.traceMu.Lock()
+	defer m.traceMu.Unlock()
+	if m.ops == nil {
+		return
+	}
+	if _, ok := m.ops[f]; !ok {
+		// Only Timers created while the trace is enabled are traced
+		if r.op != OpCreate {
+			return
+		}
+		m.traced = append(m.traced, f)
+	}
+	m.ops[f] = append(m.ops[f], r)
+}
+
+// traceName returns the name of the i-th traced Timer used in misuse reports.
+func traceName(f *fakeTimer, i int) string {
+	if label := f.Label(); label != "" {
+		return fmt.Sprintf("timer %q", label)
+	}
+	return fmt.Sprintf("timer #%d", i+1)
+}
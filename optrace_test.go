@@ -0,0 +1,51 @@
+package clock
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeT records the errors reported by AssertNoMisuse.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMock_OpTrace(t *testing.T) {
+	clock := NewMock()
+	untraced := clock.NewTimer(time.Second)
+	clock.EnableOpTrace()
+	timer := clock.NewTimer(time.Second)
+	clock.Forward(time.Second)
+	<-timer.Chan()
+	timer.Reset(time.Second)
+	timer.Stop()
+
+	assert.Equal(t, []Op{OpCreate, OpExecute, OpReset, OpStop}, clock.OpTrace(timer))
+	assert.Nil(t, clock.OpTrace(untraced))
+	assert.True(t, clock.AssertNoMisuse(t))
+}
+
+func TestMock_AssertNoMisuse(t *testing.T) {
+	clock := NewMock()
+	clock.EnableOpTrace()
+	undrained := clock.NewTimer(time.Second)
+	doubleStop := clock.AfterFunc(time.Hour, func() {})
+	clock.Forward(time.Second)
+	undrained.Reset(time.Second)
+	doubleStop.Stop()
+	doubleStop.Stop()
+
+	ft := &fakeT{}
+	assert.False(t, clock.AssertNoMisuse(ft))
+	assert.Equal(t, []string{
+		"clock: timer #1: Reset after the timer fired without draining its channel",
+		"clock: timer #2: Stop called on a timer that has already been stopped",
+	}, ft.errors)
+}
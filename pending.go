@@ -0,0 +1,125 @@
+package clock
+
+import (
+	"sort"
+	"time"
+)
+
+// EventKind describes which type of object a PendingEvent belongs to.
+type EventKind int
+
+const (
+	// TimerEvent belongs to a Timer created by NewTimer, After or AfterFunc
+	TimerEvent EventKind = iota
+	// TickerEvent belongs to a Ticker
+	TickerEvent
+)
+
+// DeliveryMode describes what happens when a PendingEvent fires.
+type DeliveryMode int
+
+const (
+	// ChannelDelivery sends the time on a channel
+	ChannelDelivery DeliveryMode = iota
+	// FuncDelivery runs a function, as done by AfterFunc and Every
+	FuncDelivery
+)
+
+// PendingEvent describes the next execution of a Timer or Ticker tracked by a Mock.
+type PendingEvent struct {
+	// At is the time of the next execution
+	At time.Time
+	// Kind tells whether the event belongs to a Timer or a Ticker
+	Kind EventKind
+	// Mode tells whether the event signals a channel or runs a function
+	Mode DeliveryMode
+	// Label is the label of the Timer or Ticker, if any
+	Label string
+}
+
+// PendingTimers returns all Timers and Tickers that have not fired yet in the order they will fire.
+// Tickers are reported once with their next execution.
+func (m *Mock) PendingTimers() []PendingEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sort.Sort(schedule{m})
+	events := make([]PendingEvent, 0, len(m.timers))
+	for _, t := range m.timers {
+		if e, ok := pendingEvent(t); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// pendingEvent describes t if it is a Timer or Ticker.
+func pendingEvent(t tracked) (PendingEvent, bool) {
+	switch v := t.(type) {
+	case *fakeTimer:
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		e := PendingEvent{At: v.due, Kind: TimerEvent, Mode: ChannelDelivery, Label: v.label}
+		if v.ch == nil {
+			e.Mode = FuncDelivery
+		}
+		return e, true
+	case *fakeTicker:
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		e := PendingEvent{At: v.next, Kind: TickerEvent, Mode: ChannelDelivery, Label: v.label}
+		if v.fn != nil {
+			e.Mode = FuncDelivery
+		}
+		return e, true
+	}
+	return PendingEvent{}, false
+}
+
+// CountTimers returns the number of pending Timers, including those created by After and AfterFunc.
+func (m *Mock) CountTimers() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, t := range m.timers {
+		if _, ok := t.(*fakeTimer); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// CountTickers returns the number of Tickers that have not been stopped.
+func (m *Mock) CountTickers() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, t := range m.timers {
+		if _, ok := t.(*fakeTicker); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// TimersWithin returns the Timers and Tickers that are due within d of the internal time, i.e. between the internal
+// time and the internal time plus d inclusive, in the order they will fire. Nothing is fired. Like in PendingTimers,
+// Tickers are reported once with their next execution.
+func (m *Mock) TimersWithin(d time.Duration) []PendingEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	horizon := m.now.Add(d)
+	var within []tracked
+	for _, t := range m.timers {
+		if at := t.NextExecution(); !at.Before(m.now) && !at.After(horizon) {
+			within = append(within, t)
+		}
+	}
+	sort.Slice(within, func(i, j int) bool { return m.before(within[i], within[j]) })
+	var events []PendingEvent
+	for _, t := range within {
+		if e, ok := pendingEvent(t); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
@@ -0,0 +1,87 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_PendingTimers(t *testing.T) {
+	clock := NewMock()
+	start := clock.Now()
+	clock.AfterFunc(time.Minute, func() {})
+	clock.NewTimer(time.Second)
+	clock.NewTicker(time.Hour)
+	stopped := clock.AfterFunc(time.Second, func() {})
+	stopped.Stop()
+
+	assert.Equal(t, []PendingEvent{
+		{At: start.Add(time.Second), Kind: TimerEvent, Mode: ChannelDelivery},
+		{At: start.Add(time.Minute), Kind: TimerEvent, Mode: FuncDelivery},
+		{At: start.Add(time.Hour), Kind: TickerEvent, Mode: ChannelDelivery},
+	}, clock.PendingTimers())
+
+	clock.Forward(time.Hour)
+	assert.Equal(t, []PendingEvent{
+		{At: start.Add(2 * time.Hour), Kind: TickerEvent, Mode: ChannelDelivery},
+	}, clock.PendingTimers())
+}
+
+func TestMock_PendingTimersLabels(t *testing.T) {
+	clock := NewMock()
+	clock.NewLabeledTimer(time.Minute, "flush")
+	clock.NewLabeledTicker(time.Second, "heartbeat")
+	clock.NewTimer(time.Hour)
+	assert.Equal(t, []PendingEvent{
+		{At: time.Unix(1, 0), Kind: TickerEvent, Mode: ChannelDelivery, Label: "heartbeat"},
+		{At: time.Unix(60, 0), Kind: TimerEvent, Mode: ChannelDelivery, Label: "flush"},
+		{At: time.Unix(3600, 0), Kind: TimerEvent, Mode: ChannelDelivery},
+	}, clock.PendingTimers())
+
+	var labels []string
+	clock.BeforeFire(func(e Executer, _ time.Time) { labels = append(labels, e.(Labeled).Label()) })
+	clock.Forward(time.Minute)
+	assert.Len(t, labels, 61)
+	assert.Equal(t, "heartbeat", labels[0])
+	// Both are due after a minute, the timer was created first
+	assert.Equal(t, "flush", labels[59])
+}
+
+func TestMock_TimersWithin(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(100, 0))
+	clock.NewLabeledTimer(30*time.Second, "soon")
+	clock.NewLabeledTimer(time.Minute, "edge")
+	clock.NewLabeledTimer(time.Hour, "later")
+	clock.NewLabeledTicker(10*time.Second, "ticker")
+
+	var labels []string
+	for _, e := range clock.TimersWithin(time.Minute) {
+		labels = append(labels, e.Label)
+	}
+	assert.Equal(t, []string{"ticker", "soon", "edge"}, labels)
+	assert.Len(t, clock.PendingTimers(), 4)
+	assert.Empty(t, clock.TimersWithin(5*time.Second))
+}
+
+func TestMock_CountTimersTickers(t *testing.T) {
+	clock := NewMock()
+	clock.NewTimer(time.Second)
+	clock.After(time.Minute)
+	stopped := clock.AfterFunc(time.Hour, func() {})
+	ticker := clock.NewTicker(time.Second)
+	clock.NewTicker(time.Minute)
+	assert.Equal(t, 3, clock.CountTimers())
+	assert.Equal(t, 2, clock.CountTickers())
+
+	stopped.Stop()
+	ticker.Stop()
+	assert.Equal(t, 2, clock.CountTimers())
+	assert.Equal(t, 1, clock.CountTickers())
+
+	// Fired Timers are not counted anymore
+	clock.Forward(time.Minute)
+	assert.Equal(t, 0, clock.CountTimers())
+	assert.Equal(t, 1, clock.CountTickers())
+}
@@ -0,0 +1,112 @@
+package clock
+
+import "time"
+
+// shifter is implemented by Executers whose next execution can be moved by a fixed amount of time.
+type shifter interface {
+	shift(d time.Duration)
+}
+
+// isInternal reports whether t is an Executer the Mock schedules for itself, like the ones created by PlanSet and
+// InjectPause, as opposed to the Timers and Tickers of its users. Internal Executers are not counted as pending.
+func isInternal(t tracked) bool {
+	switch t.(type) {
+	case *plannedSet, *injectedPause:
+		return true
+	}
+	return false
+}
+
+// plannedSet is an Executer that changes the internal time of a Mock when it is reached.
+type plannedSet struct {
+	tracking
+	clock *Mock
+	at    time.Time
+	to    time.Time
+	fire  bool
+}
+
+// PlanSet schedules a change of the internal time: as soon as the internal time reaches at, it jumps to to. This can
+// be used to script administrative clock changes up front.
+//
+// If the jump happens during a call to Forward, the remaining duration is added after the jump, so forwarding past
+// at ends up at to plus the amount forwarded beyond at.
+//
+// If fire is true, the jump behaves like Set and Timers and Tickers that become due are fired. If fire is false, all
+// pending Timers and Tickers are moved by the size of the jump, so they fire after their original remaining duration,
+// the same way timers of the time package are unaffected by changes of the wall clock.
+func (m *Mock) PlanSet(at, to time.Time, fire bool) {
+	m.addTimer(&plannedSet{clock: m, at: at, to: to, fire: fire})
+}
+
+// NextExecution returns the time at which the internal time is changed.
+func (p *plannedSet) NextExecution() time.Time {
+	return p.at
+}
+
+// Execute changes the internal time of the Mock.
+func (p *plannedSet) Execute(time.Time) {
+	m := p.clock
+	m.removeTimer(p)
+	jump := p.to.Sub(p.at)
+
+	m.mu.Lock()
+	m.setNow(m.now.Add(jump))
+	if jump > 0 {
+		m.simulated += jump
+	}
+	timers := make([]tracked, len(m.timers))
+	copy(timers, m.timers)
+	m.mu.Unlock()
+
+	if p.fire {
+		return
+	}
+	for _, t := range timers {
+		if s, ok := t.(shifter); ok {
+			s.shift(jump)
+		}
+	}
+}
+
+// injectedPause is an Executer that delays all Timers and Tickers that become due during a pause.
+type injectedPause struct {
+	tracking
+	clock *Mock
+	at    time.Time
+	until time.Time
+}
+
+// InjectPause models a stop-the-world pause, e.g. caused by garbage collection: when the internal time reaches at,
+// all Timers and Tickers that become due before at+duration are delayed and fire together at at+duration.
+// Only the firing of Timers and Tickers is affected, the internal time passes as usual.
+// A Ticker that would tick multiple times during the pause ticks once at the end of the pause and continues with its
+// period from there.
+func (m *Mock) InjectPause(at time.Time, duration time.Duration) {
+	m.addTimer(&injectedPause{clock: m, at: at, until: at.Add(duration)})
+}
+
+// NextExecution returns the start of the pause.
+func (p *injectedPause) NextExecution() time.Time {
+	return p.at
+}
+
+// Execute delays the Timers and Tickers that are due during the pause.
+func (p *injectedPause) Execute(time.Time) {
+	m := p.clock
+	m.removeTimer(p)
+	m.mu.RLock()
+	timers := make([]tracked, len(m.timers))
+	copy(timers, m.timers)
+	m.mu.RUnlock()
+
+	for _, t := range timers {
+		s, ok := t.(shifter)
+		if !ok {
+			continue
+		}
+		if next := t.NextExecution(); next.Before(p.until) {
+			s.shift(p.until.Sub(next))
+		}
+	}
+}
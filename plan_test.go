@@ -0,0 +1,132 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_PlanSet(t *testing.T) {
+	tests := []struct {
+		fire  bool
+		fired []time.Duration
+		// fired after forwarding another 30 minutes
+		later []time.Duration
+	}{
+		{true, []time.Duration{30 * time.Minute, 90 * time.Minute}, []time.Duration{30 * time.Minute, 90 * time.Minute}},
+		{false, []time.Duration{30 * time.Minute}, []time.Duration{30 * time.Minute, 90 * time.Minute}},
+	}
+	for _, test := range tests {
+		clock := NewMock()
+		start := clock.Now()
+		var fired []time.Duration
+		for _, d := range []time.Duration{30 * time.Minute, 90 * time.Minute, 3 * time.Hour} {
+			d := d
+			clock.AfterFunc(d, func() { fired = append(fired, d) })
+		}
+		clock.PlanSet(start.Add(time.Hour), start.Add(2*time.Hour), test.fire)
+
+		clock.Forward(time.Hour)
+		assert.Equal(t, start.Add(2*time.Hour), clock.Now())
+		assert.Equal(t, test.fired, fired)
+
+		clock.Forward(30 * time.Minute)
+		assert.Equal(t, test.later, fired)
+	}
+}
+
+func TestMock_PlanSetRemainder(t *testing.T) {
+	clock := NewMock()
+	start := clock.Now()
+	clock.PlanSet(start.Add(time.Hour), start.Add(-time.Hour), true)
+	fired := false
+	clock.AfterFunc(90*time.Minute, func() { fired = true })
+
+	// The jump back happens after one hour, the remaining hour is forwarded afterwards
+	clock.Forward(2 * time.Hour)
+	assert.Equal(t, start, clock.Now())
+	assert.False(t, fired)
+	assert.Equal(t, 1, clock.Len())
+}
+
+func TestMock_PlanSetNotPending(t *testing.T) {
+	clock := NewMock()
+	start := clock.Now()
+	idle := 0
+	clock.OnIdle(func() { idle++ })
+	clock.PlanSet(start.Add(time.Hour), start.Add(2*time.Hour), true)
+
+	assert.Equal(t, 0, clock.Len())
+	assert.Equal(t, 0, clock.CountTimers())
+	assert.Empty(t, clock.PendingTimers())
+	_, ok := clock.DurationToNext()
+	assert.False(t, ok)
+	_, pending := clock.State()
+	assert.Equal(t, 0, pending)
+
+	clock.AfterFunc(time.Minute, func() {})
+	d, ok := clock.DurationToNext()
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, d)
+	assert.Equal(t, 1, clock.Len())
+
+	// The Mock is idle once the Timer has fired, although the change of the internal time is still pending
+	clock.Forward(time.Minute)
+	assert.Equal(t, 1, idle)
+	clock.RunUntilDoneN(1)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+	clock.Forward(time.Hour)
+	assert.Equal(t, start.Add(2*time.Hour+time.Minute), clock.Now())
+}
+
+func TestMock_InjectPause(t *testing.T) {
+	clock := NewMock()
+	start := clock.Now()
+	var timers []Timer
+	for _, d := range []time.Duration{10, 20, 30, 50} {
+		timers = append(timers, clock.NewTimer(d*time.Second))
+	}
+	ticker := clock.NewBufferedTicker(4*time.Second, 10)
+	clock.InjectPause(start.Add(15*time.Second), 20*time.Second)
+
+	clock.Forward(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+	expected := []time.Duration{10, 35, 35, 50}
+	for i, timer := range timers {
+		assert.Equal(t, start.Add(expected[i]*time.Second), <-timer.Chan())
+	}
+
+	// The ticker ticks at 4, 8 and 12 seconds, once at the end of the pause and then every 4 seconds
+	var ticks []time.Duration
+	for len(ticker.Chan()) > 0 {
+		ticks = append(ticks, (<-ticker.Chan()).Sub(start)/time.Second)
+	}
+	assert.Equal(t, []time.Duration{4, 8, 12, 35, 39, 43, 47, 51, 55, 59}, ticks)
+	ticker.Stop()
+}
+
+func TestMock_InjectPauseNotPending(t *testing.T) {
+	clock := NewMock()
+	start := clock.Now()
+	idle := 0
+	clock.OnIdle(func() { idle++ })
+	clock.InjectPause(start.Add(time.Hour), time.Minute)
+
+	assert.Equal(t, 0, clock.Len())
+	assert.Equal(t, 0, clock.CountTimers())
+	_, ok := clock.DurationToNext()
+	assert.False(t, ok)
+
+	timer := clock.NewTimer(time.Hour + time.Second)
+	d, ok := clock.DurationToNext()
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour+time.Second, d)
+	assert.Equal(t, 1, clock.Len())
+
+	// The Timer is delayed until the end of the pause
+	clock.Forward(2 * time.Hour)
+	assert.Equal(t, start.Add(time.Hour+time.Minute), <-timer.Chan())
+	assert.Equal(t, 1, idle)
+	assert.Equal(t, 0, clock.Len())
+}
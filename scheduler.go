@@ -0,0 +1,114 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Scheduler runs recurring jobs, e.g. every hour or every day at a given wall-clock time. All jobs are driven by
+// timers of the underlying Clock, so a Mock can be used to trigger them deterministically.
+type Scheduler struct {
+	mu      sync.Mutex
+	clock   Clock
+	jobs    []*job
+	stopped bool
+}
+
+// job is a single recurring job of a Scheduler.
+type job struct {
+	fn func()
+	// next calculates the following execution time based on the previous one
+	next  func(time.Time) time.Time
+	at    time.Time
+	timer Timer
+}
+
+// NewScheduler returns a Scheduler that uses clk to schedule its jobs.
+func NewScheduler(clk Clock) *Scheduler {
+	return &Scheduler{clock: clk}
+}
+
+// Every registers fn to be run every d, starting d from now. Like Mock.Every, it panics if d <= 0.
+func (s *Scheduler) Every(d time.Duration, fn func()) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Scheduler.Every")
+	}
+	j := &job{fn: fn, next: func(t time.Time) time.Time { return t.Add(d) }}
+	j.at = j.next(s.clock.Now())
+	s.add(j)
+}
+
+// At registers fn to be run every day at the given hour and minute. The wall-clock time is interpreted in the
+// location of the time returned by the clock's Now.
+func (s *Scheduler) At(hour, minute int, fn func()) {
+	now := s.clock.Now()
+	next := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d+1, hour, minute, 0, 0, now.Location())
+	}
+	y, m, d := now.Date()
+	at := time.Date(y, m, d, hour, minute, 0, 0, now.Location())
+	if !at.After(now) {
+		at = next(at)
+	}
+	s.add(&job{fn: fn, next: next, at: at})
+}
+
+// Stop stops all jobs of the Scheduler. Jobs that are currently running are not interrupted.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for _, j := range s.jobs {
+		// The timer of a job that has just been added might not be armed yet, schedule stops it then
+		if j.timer != nil {
+			j.timer.Stop()
+		}
+	}
+	s.jobs = nil
+}
+
+// add registers a job and schedules its first execution.
+func (s *Scheduler) add(j *job) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.jobs = append(s.jobs, j)
+	at := j.at
+	s.mu.Unlock()
+	s.schedule(j, at)
+}
+
+// schedule arms a timer for the execution of a job at the given time. The timer is armed without holding s.mu, since
+// it might fire right away if at has already passed. It is only stored in the job if at is still its next execution,
+// since a timer that fires right away might have scheduled the following execution already.
+func (s *Scheduler) schedule(j *job, at time.Time) {
+	timer := s.clock.AfterFunc(s.clock.Until(at), func() { s.run(j, at) })
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		timer.Stop()
+		return
+	}
+	if j.at.Equal(at) {
+		j.timer = timer
+	}
+}
+
+// run executes a job and schedules its next execution.
+// The next execution is calculated from the previous one instead of the current time. This way, a Mock that is
+// forwarded across multiple executions at once runs the job once for each execution.
+func (s *Scheduler) run(j *job, at time.Time) {
+	j.fn()
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	next := j.next(at)
+	j.at = next
+	s.mu.Unlock()
+	s.schedule(j, next)
+}
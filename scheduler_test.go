@@ -0,0 +1,81 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_Every(t *testing.T) {
+	clock := NewMock()
+	s := NewScheduler(clock)
+	defer s.Stop()
+	count := 0
+	s.Every(time.Hour, func() { count++ })
+
+	clock.Forward(59 * time.Minute)
+	assert.Equal(t, 0, count)
+	clock.Forward(time.Minute)
+	assert.Equal(t, 1, count)
+	clock.Forward(5 * time.Hour)
+	assert.Equal(t, 6, count)
+}
+
+func TestScheduler_At(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC))
+	s := NewScheduler(clock)
+	defer s.Stop()
+	var fired []time.Time
+	s.At(9, 30, func() { fired = append(fired, clock.Now()) })
+
+	// Forward hour by hour across three days
+	for i := 0; i < 72; i++ {
+		clock.Forward(time.Hour)
+	}
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 3, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2019, 3, 3, 10, 0, 0, 0, time.UTC),
+		time.Date(2019, 3, 4, 10, 0, 0, 0, time.UTC),
+	}, fired)
+
+	// Forwarding across multiple days at once runs the job once per day
+	clock.Forward(4 * 24 * time.Hour)
+	assert.Len(t, fired, 7)
+}
+
+func TestScheduler_Stop(t *testing.T) {
+	clock := NewMock()
+	s := NewScheduler(clock)
+	count := 0
+	s.Every(time.Minute, func() { count++ })
+	s.At(0, 0, func() { count++ })
+	s.Stop()
+	s.Every(time.Minute, func() { count++ })
+
+	clock.Forward(48 * time.Hour)
+	assert.Equal(t, 0, count)
+	assert.Zero(t, clock.Len())
+}
+
+func TestScheduler_EveryNonPositive(t *testing.T) {
+	s := NewScheduler(NewMock())
+	defer s.Stop()
+	assert.PanicsWithValue(t, "clock: non-positive interval for Scheduler.Every", func() { s.Every(0, func() {}) })
+	assert.Panics(t, func() { s.Every(-time.Second, func() {}) })
+}
+
+func TestScheduler_CatchUp(t *testing.T) {
+	clock := NewMock()
+	s := NewScheduler(clock)
+	defer s.Stop()
+	var runs []time.Time
+	s.Every(time.Hour, func() { runs = append(runs, clock.Now()) })
+
+	// The job is due multiple times at once, every execution runs during the same Forward
+	clock.Forward(3*time.Hour + time.Minute)
+	assert.Len(t, runs, 3)
+	clock.Forward(59 * time.Minute)
+	assert.Len(t, runs, 4)
+}
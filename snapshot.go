@@ -0,0 +1,106 @@
+package clock
+
+import "time"
+
+// Snapshot is the scheduling state of a Mock at one point in time: its internal time and all pending Timers and
+// Tickers with their next execution. It is created by Mock.Snapshot and can be passed to Mock.Restore.
+type Snapshot struct {
+	now     time.Time
+	seq     uint64
+	entries []snapshotEntry
+}
+
+// snapshotEntry is the state of a single pending Executer.
+type snapshotEntry struct {
+	t    tracked
+	seq  uint64
+	next time.Time
+}
+
+// rearmer is implemented by Executers that can be made pending again with a given next execution.
+type rearmer interface {
+	rearm(next time.Time)
+}
+
+// Snapshot captures the internal time and the pending Timers and Tickers, so the Mock can be brought back to this
+// state with Restore. This allows running several branches of a simulation from the same starting point.
+//
+// Channels and callbacks can't be copied. Restored Timers and Tickers are the same objects as before and keep
+// sending on their original channels or running their original callbacks.
+func (m *Mock) Snapshot() *Snapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s := &Snapshot{now: m.now, seq: m.seq, entries: make([]snapshotEntry, 0, len(m.timers))}
+	for _, t := range m.timers {
+		s.entries = append(s.entries, snapshotEntry{t: t, seq: t.track().seq, next: t.NextExecution()})
+	}
+	return s
+}
+
+// Restore brings the Mock back to the state captured by s. The internal time is set to the captured time without
+// firing anything. Timers and Tickers that were pending when s was taken are pending again with their captured next
+// execution, even if they have fired or been stopped since. All other Timers and Tickers are stopped.
+//
+// Values that have been sent on channels since s was taken are not taken back. Restore is serialized with the calls
+// that move the internal time, see Mock.
+func (m *Mock) Restore(s *Snapshot) {
+	m.serialize(func() {
+		m.mu.Lock()
+		current := m.timers
+		m.timers = nil
+		m.setNow(s.now)
+		m.seq = s.seq
+		m.mu.Unlock()
+
+		captured := make(map[tracked]bool, len(s.entries))
+		for _, e := range s.entries {
+			captured[e.t] = true
+		}
+		for _, t := range current {
+			if captured[t] {
+				continue
+			}
+			switch v := t.(type) {
+			case *fakeTimer:
+				v.mu.Lock()
+				v.stopped = true
+				v.mu.Unlock()
+			case *fakeTicker:
+				v.mu.Lock()
+				v.stopped = true
+				v.mu.Unlock()
+			}
+		}
+		for _, e := range s.entries {
+			if r, ok := e.t.(rearmer); ok {
+				r.rearm(e.next)
+			}
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.timers = make([]tracked, 0, len(s.entries))
+		for _, e := range s.entries {
+			e.t.track().seq = e.seq
+			e.t.track().index = len(m.timers)
+			m.timers = append(m.timers, e.t)
+		}
+	})
+}
+
+// rearm makes the Timer pending again with the given due time.
+func (f *fakeTimer) rearm(next time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.due = next
+	f.stopped = false
+	f.fired = false
+}
+
+// rearm makes the Ticker pending again with the given next tick.
+func (f *fakeTicker) rearm(next time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.next = next
+	f.stopped = false
+}
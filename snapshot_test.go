@@ -0,0 +1,62 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_SnapshotRestore(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Minute)
+	ticker := clock.NewTicker(time.Hour)
+	s := clock.Snapshot()
+
+	// First branch: the timer fires and a new one is created
+	clock.Forward(time.Minute)
+	assert.Equal(t, time.Unix(60, 0), <-timer.Chan())
+	var fired bool
+	clock.AfterFunc(time.Second, func() { fired = true })
+
+	clock.Restore(s)
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+	assert.Equal(t, 2, clock.Len())
+	assert.Equal(t, []PendingEvent{
+		{At: time.Unix(60, 0), Kind: TimerEvent, Mode: ChannelDelivery},
+		{At: time.Unix(3600, 0), Kind: TickerEvent, Mode: ChannelDelivery},
+	}, clock.PendingTimers())
+
+	// Second branch: the timer is pending again and fires on its original channel
+	clock.Forward(30 * time.Second)
+	assert.Empty(t, timer.Chan())
+	assert.True(t, timer.Stop())
+	clock.Forward(time.Hour)
+	assert.False(t, fired)
+	assert.Equal(t, time.Unix(3600, 0), <-ticker.Chan())
+}
+
+func TestMock_RestoreStopped(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Minute)
+	s := clock.Snapshot()
+	timer.Stop()
+
+	clock.Restore(s)
+	clock.Forward(time.Minute)
+	assert.Equal(t, time.Unix(60, 0), <-timer.Chan())
+}
+
+func TestMock_RestoreFired(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Minute).(StatefulTimer)
+	s := clock.Snapshot()
+	clock.Forward(time.Minute)
+	assert.Equal(t, Fired, timer.State())
+	<-timer.Chan()
+
+	clock.Restore(s)
+	assert.Equal(t, Pending, timer.State())
+	clock.Forward(time.Minute)
+	assert.Equal(t, Fired, timer.State())
+}
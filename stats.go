@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// MockStats describes how a Mock has been driven.
+type MockStats struct {
+	// Forwards is the number of calls to Forward and ForwardTo
+	Forwards int
+	// Sets is the number of calls to Set
+	Sets int
+	// Advanced is the total amount of time the internal time has been moved forward
+	Advanced time.Duration
+	// TimerFires is the number of times a Timer has fired
+	TimerFires int
+	// TickerFires is the number of ticks of all Tickers, including ticks that were dropped because the channel was full
+	TickerFires int
+	// DroppedSends is the number of values of Timers that have been dropped because the channel was full
+	DroppedSends int
+}
+
+// Stats returns counters about how the Mock has been driven. It can be used to assert that a simulation doesn't
+// advance the internal time more often than necessary.
+func (m *Mock) Stats() MockStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return MockStats{
+		Forwards:     m.forwards,
+		Sets:         m.sets,
+		Advanced:     m.simulated,
+		TimerFires:   int(atomic.LoadInt64(&m.timerFires)),
+		TickerFires:  int(atomic.LoadInt64(&m.tickerFires)),
+		DroppedSends: int(atomic.LoadInt64(&m.droppedSends)),
+	}
+}
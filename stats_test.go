@@ -0,0 +1,30 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_Stats(t *testing.T) {
+	clock := NewMock()
+	clock.NewTicker(time.Minute)
+	clock.AfterFunc(90*time.Second, func() {})
+	timer := clock.NewTimer(time.Hour)
+	clock.After(time.Second)
+	timer.Stop()
+
+	clock.Forward(time.Minute)
+	clock.Forward(2 * time.Minute)
+	assert.NoError(t, clock.ForwardTo(time.Unix(240, 0)))
+	clock.Set(time.Unix(60, 0))
+
+	assert.Equal(t, MockStats{
+		Forwards:    3,
+		Sets:        1,
+		Advanced:    4 * time.Minute,
+		TimerFires:  2,
+		TickerFires: 4,
+	}, clock.Stats())
+}
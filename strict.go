@@ -0,0 +1,154 @@
+package clock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// strictClock wraps a Clock and panics whenever it receives a non-positive duration.
+type strictClock struct {
+	Clock
+	allowZero bool
+}
+
+// StrictOption configures a Clock returned by NewStrict.
+type StrictOption func(*strictClock)
+
+// AllowZero makes a strict Clock accept zero durations. Negative durations still cause a panic.
+func AllowZero() StrictOption {
+	return func(c *strictClock) { c.allowZero = true }
+}
+
+// NewStrict returns a Clock that delegates to base, but panics if a zero or negative duration is passed to any of
+// its functions that wait for a duration. This surfaces logic errors that compute invalid durations early in tests.
+func NewStrict(base Clock, opts ...StrictOption) Clock {
+	c := &strictClock{Clock: base}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// check panics if d is not a valid duration for the function fn.
+func (c *strictClock) check(fn string, d time.Duration) {
+	if d < 0 || (d == 0 && !c.allowZero) {
+		panic(fmt.Sprintf("clock: invalid duration %v passed to %s", d, fn))
+	}
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (c *strictClock) After(d time.Duration) <-chan time.Time {
+	c.check("After", d)
+	return c.Clock.After(d)
+}
+
+// AfterAny waits for the shortest of the durations to elapse and then sends the current time on the returned channel.
+func (c *strictClock) AfterAny(ds ...time.Duration) <-chan time.Time {
+	for _, d := range ds {
+		c.check("AfterAny", d)
+	}
+	return c.Clock.AfterAny(ds...)
+}
+
+// AfterAll waits for the longest of the durations to elapse and then sends the current time on the returned channel.
+func (c *strictClock) AfterAll(ds ...time.Duration) <-chan time.Time {
+	for _, d := range ds {
+		c.check("AfterAll", d)
+	}
+	return c.Clock.AfterAll(ds...)
+}
+
+// AfterFunc waits for the duration to elapse and then executes a function.
+// A Timer is returned that can be stopped.
+func (c *strictClock) AfterFunc(d time.Duration, fn func()) Timer {
+	c.check("AfterFunc", d)
+	return c.Clock.AfterFunc(d, fn)
+}
+
+// Sleep pauses the current goroutine for at least the duration d.
+func (c *strictClock) Sleep(d time.Duration) {
+	c.check("Sleep", d)
+	c.Clock.Sleep(d)
+}
+
+// SleepContext pauses the current goroutine for at least the duration d or until ctx is done.
+func (c *strictClock) SleepContext(ctx context.Context, d time.Duration) error {
+	c.check("SleepContext", d)
+	return c.Clock.SleepContext(ctx, d)
+}
+
+// WaitTimeout waits for wg until the duration d has elapsed.
+func (c *strictClock) WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool {
+	c.check("WaitTimeout", d)
+	return c.Clock.WaitTimeout(wg, d)
+}
+
+// Every calls fn every d until the returned cancel function is called.
+func (c *strictClock) Every(d time.Duration, fn func()) func() {
+	c.check("Every", d)
+	return c.Clock.Every(d, fn)
+}
+
+// NewTicker returns a new Ticker containing a channel that will send the
+// time with a period specified by the duration argument.
+func (c *strictClock) NewTicker(d time.Duration) Ticker {
+	c.check("NewTicker", d)
+	return c.Clock.NewTicker(d)
+}
+
+// NewTickerContext returns a new Ticker that is stopped when ctx is done.
+func (c *strictClock) NewTickerContext(ctx context.Context, d time.Duration) Ticker {
+	c.check("NewTickerContext", d)
+	return c.Clock.NewTickerContext(ctx, d)
+}
+
+// NewTickerChan returns a new Ticker and its channel.
+func (c *strictClock) NewTickerChan(d time.Duration) (Ticker, <-chan time.Time) {
+	c.check("NewTickerChan", d)
+	return c.Clock.NewTickerChan(d)
+}
+
+// NewTickerAt returns a new Ticker that sends the time on its channel at start and then with a period
+// specified by the duration argument.
+func (c *strictClock) NewTickerAt(start time.Time, d time.Duration) Ticker {
+	c.check("NewTickerAt", d)
+	return c.Clock.NewTickerAt(start, d)
+}
+
+// NewTickerImmediate returns a new Ticker that also ticks right away.
+func (c *strictClock) NewTickerImmediate(d time.Duration) Ticker {
+	c.check("NewTickerImmediate", d)
+	return c.Clock.NewTickerImmediate(d)
+}
+
+// NewTimer creates a new Timer that will send
+// the current time on its channel after at least duration d.
+func (c *strictClock) NewTimer(d time.Duration) Timer {
+	c.check("NewTimer", d)
+	return c.Clock.NewTimer(d)
+}
+
+// NewTimerReset creates a new Timer and a function that safely resets it. The function panics on invalid durations
+// as well.
+func (c *strictClock) NewTimerReset(d time.Duration) (Timer, func(time.Duration)) {
+	c.check("NewTimerReset", d)
+	t, reset := c.Clock.NewTimerReset(d)
+	return t, func(d time.Duration) {
+		c.check("NewTimerReset", d)
+		reset(d)
+	}
+}
+
+// NewTimerCapped creates a new Timer that fires after d, but no later than notAfter.
+func (c *strictClock) NewTimerCapped(d time.Duration, notAfter time.Time) Timer {
+	c.check("NewTimerCapped", d)
+	return c.Clock.NewTimerCapped(d, notAfter)
+}
+
+// SleepUntilNext pauses the current goroutine until the next multiple of period since the Unix epoch.
+func (c *strictClock) SleepUntilNext(period time.Duration) {
+	c.check("SleepUntilNext", period)
+	c.Clock.SleepUntilNext(period)
+}
@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrict_Panics(t *testing.T) {
+	c := NewStrict(NewMock())
+	for _, d := range []time.Duration{0, -time.Second} {
+		assert.Panics(t, func() { c.After(d) })
+		assert.Panics(t, func() { c.AfterFunc(d, func() {}) })
+		assert.Panics(t, func() { c.Sleep(d) })
+		assert.Panics(t, func() { c.SleepContext(context.Background(), d) })
+		assert.Panics(t, func() { c.WaitTimeout(&sync.WaitGroup{}, d) })
+		assert.Panics(t, func() { c.Every(d, func() {}) })
+		assert.Panics(t, func() { c.NewTicker(d) })
+		assert.Panics(t, func() { c.NewTickerChan(d) })
+		assert.Panics(t, func() { c.NewTickerContext(context.Background(), d) })
+		assert.Panics(t, func() { c.NewTickerImmediate(d) })
+		assert.Panics(t, func() { c.NewTimer(d) })
+		assert.Panics(t, func() { c.NewTimerReset(d) })
+		assert.Panics(t, func() { c.NewTimerCapped(d, time.Unix(1e9, 0)) })
+		assert.Panics(t, func() { c.SleepUntilNext(d) })
+	}
+	assert.PanicsWithValue(t, "clock: invalid duration -1s passed to NewTimer", func() { c.NewTimer(-time.Second) })
+}
+
+func TestStrict_PassThrough(t *testing.T) {
+	mock := NewMock()
+	c := NewStrict(mock)
+	fired := false
+	c.AfterFunc(time.Second, func() { fired = true })
+	timer := c.NewTimer(time.Second)
+	ticker := c.NewTicker(time.Second)
+	c.After(time.Second)
+	assert.Equal(t, 4, mock.Len())
+
+	mock.Forward(time.Second)
+	assert.True(t, fired)
+	assert.Equal(t, mock.Now(), <-timer.Chan())
+	assert.Equal(t, mock.Now(), <-ticker.Chan())
+	assert.Equal(t, mock.Now(), c.Now())
+}
+
+func TestStrict_AllowZero(t *testing.T) {
+	c := NewStrict(NewMock(), AllowZero())
+	assert.NotPanics(t, func() { c.Sleep(0) })
+	assert.NotPanics(t, func() { <-c.After(0) })
+	assert.Panics(t, func() { c.Sleep(-time.Nanosecond) })
+}
+
+func TestStrict_NewTickerAt(t *testing.T) {
+	c := NewStrict(NewMock())
+	assert.Panics(t, func() { c.NewTickerAt(time.Unix(0, 0), 0) })
+	assert.NotPanics(t, func() { c.NewTickerAt(time.Unix(0, 0), time.Second).Stop() })
+}
+
+func TestStrict_AfterAnyAll(t *testing.T) {
+	c := NewStrict(NewMock())
+	assert.Panics(t, func() { c.AfterAny(time.Second, 0) })
+	assert.Panics(t, func() { c.AfterAll(-time.Second, time.Second) })
+	assert.NotPanics(t, func() { c.AfterAll(time.Second, time.Minute) })
+}
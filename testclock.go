@@ -0,0 +1,21 @@
+package clock
+
+// TestCleaner is the subset of testing.TB needed by NewTestClock. It avoids importing the testing package.
+type TestCleaner interface {
+	Cleanup(func())
+}
+
+// NewTestClock returns a Mock whose Timers and Tickers are stopped automatically when the test finishes. If t also
+// implements TestingT, like testing.TB does, an error is reported for every Ticker that was still running at that
+// point. A running Ticker usually means that the goroutine reading its channel leaks, so this check catches leaks
+// on a best-effort basis. Goroutines blocked on a Timer or Ticker channel can't be detected directly.
+func NewTestClock(t TestCleaner, opts ...Option) *Mock {
+	m := NewMock(opts...)
+	t.Cleanup(func() {
+		n := m.stopAll()
+		if r, ok := t.(TestingT); ok && n > 0 {
+			r.Errorf("clock: %d Ticker(s) still running at the end of the test", n)
+		}
+	})
+	return m
+}
@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTB collects cleanup functions and errors like testing.TB.
+type fakeTB struct {
+	fakeT
+	cleanups []func()
+}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestNewTestClock(t *testing.T) {
+	tb := &fakeTB{}
+	clock := NewTestClock(tb)
+	timer := clock.NewTimer(time.Minute)
+	clock.AfterFunc(time.Hour, func() {})
+	assert.Equal(t, 2, clock.Len())
+
+	tb.finish()
+	assert.Zero(t, clock.Len())
+	assert.False(t, timer.Stop())
+	assert.Empty(t, tb.errors)
+}
+
+func TestNewTestClock_RunningTicker(t *testing.T) {
+	tb := &fakeTB{}
+	clock := NewTestClock(tb)
+	clock.NewTicker(time.Second)
+	clock.NewTicker(time.Second).Stop()
+
+	tb.finish()
+	assert.Zero(t, clock.Len())
+	assert.Equal(t, []string{"clock: 1 Ticker(s) still running at the end of the test"}, tb.errors)
+}
+
+func TestNewTestClock_Testing(t *testing.T) {
+	clock := NewTestClock(t)
+	clock.NewTimer(time.Minute)
+}
@@ -1,10 +1,16 @@
 package clock
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// ErrNonPositiveInterval is returned by TryNewTicker if the interval is not positive.
+var ErrNonPositiveInterval = errors.New("clock: non-positive interval for NewTicker")
+
 // Ticker is an abstraction for the type time.Ticker that can be mocked for tests.
 type Ticker interface {
 	// Chan returns the readonly channel of the ticker
@@ -13,6 +19,35 @@ type Ticker interface {
 	Stop()
 }
 
+// TickerPolicy determines how a fake Ticker behaves when the internal time of a Mock is forwarded across multiple
+// periods at once.
+type TickerPolicy int
+
+const (
+	// CatchUp delivers a tick for every period that has elapsed, as long as the channel has room for it
+	CatchUp TickerPolicy = iota
+	// Coalesce delivers a single tick with the time of the latest elapsed period, like a time.Ticker does for a
+	// slow reader
+	Coalesce
+)
+
+// TickerOrderPolicy determines the order in which the Tickers of a Mock fire when they are due at the same instant.
+type TickerOrderPolicy int
+
+const (
+	// InsertionOrder fires Tickers in the order they have been created, which is the default
+	InsertionOrder TickerOrderPolicy = iota
+	// PeriodAscending fires Tickers with shorter periods first. Tickers with the same period fire in the order they
+	// have been created. Timers due at the same instant fire before all Tickers
+	PeriodAscending
+)
+
+// TickerC returns the channel of t. It eases migrating code that reads the field time.Ticker.C: replace t.C with
+// clock.TickerC(t).
+func TickerC(t Ticker) <-chan time.Time {
+	return t.Chan()
+}
+
 // realTicker is just the type time.Ticker and implements the Ticker interface.
 type realTicker struct {
 	*time.Ticker
@@ -23,14 +58,142 @@ func (r *realTicker) Chan() <-chan time.Time {
 	return r.C
 }
 
+// delayedTicker is a Ticker based on the time package whose first tick is delayed.
+type delayedTicker struct {
+	ch   chan time.Time
+	stop chan struct{}
+	once sync.Once
+}
+
+// newDelayedTicker returns a Ticker that ticks after delay and then with a period of d.
+// Like time.NewTicker, it panics if d <= 0.
+func newDelayedTicker(delay, d time.Duration) *delayedTicker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	t := &delayedTicker{ch: make(chan time.Time, 1), stop: make(chan struct{})}
+	go t.run(delay, d)
+	return t
+}
+
+// run sends the ticks until the Ticker is stopped.
+func (t *delayedTicker) run(delay, d time.Duration) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case now := <-timer.C:
+		t.send(now)
+	case <-t.stop:
+		return
+	}
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			t.send(now)
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// send sends a tick, dropping it if the reader is too slow like time.Ticker does.
+func (t *delayedTicker) send(now time.Time) {
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+// Chan returns the readonly channel of the ticker
+func (t *delayedTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+// Stop stops the ticker. No more events will be sent through the channel
+func (t *delayedTicker) Stop() {
+	t.once.Do(func() { close(t.stop) })
+}
+
+// every calls fn on every tick of t in a new goroutine until the returned function is called. The function stops t.
+func every(t Ticker, fn func()) func() {
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-t.Chan():
+				fn()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.Stop()
+			close(stop)
+		})
+	}
+}
+
+// contextTicker is a Ticker that is stopped when its context is done.
+type contextTicker struct {
+	Ticker
+	once sync.Once
+	// stopped is closed when the Ticker is stopped
+	stopped chan struct{}
+}
+
+// newContextTicker wraps t in a Ticker that is stopped when ctx is done.
+func newContextTicker(ctx context.Context, t Ticker) *contextTicker {
+	c := &contextTicker{Ticker: t, stopped: make(chan struct{})}
+	if ctx.Err() != nil {
+		c.Stop()
+		return c
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Stop()
+		case <-c.stopped:
+		}
+	}()
+	return c
+}
+
+// Stop stops the ticker. No more events will be sent through the channel
+func (c *contextTicker) Stop() {
+	c.once.Do(func() {
+		c.Ticker.Stop()
+		close(c.stopped)
+	})
+}
+
 // fakeTicker is a fake implementation of Ticker based on the time mocking in Mock.
 type fakeTicker struct {
+	tracking
 	mu      sync.RWMutex
 	ch      chan time.Time
 	clock   *Mock
 	d       time.Duration
 	next    time.Time
 	stopped bool
+	// label identifies the Ticker in debugging output
+	label string
+	// policy overrides the TickerPolicy of the Mock if set
+	policy *TickerPolicy
+	// interval returns the period until the next tick if set. It replaces the fixed period d.
+	interval func() time.Duration
+	// window suppresses ticks that are less than window after the last delivered tick
+	window time.Duration
+	// delivered is the time of the last delivered tick, it is only set if window is set
+	delivered *time.Time
+	// fn is called on every tick instead of sending on ch if set, as done by Every
+	fn func()
+	// ctx stops the Ticker before its next tick once it is done if set, as done by NewTickerContext
+	ctx context.Context
 }
 
 // Chan returns the readonly channel of the ticker.
@@ -39,6 +202,11 @@ func (f *fakeTicker) Chan() <-chan time.Time {
 }
 
 // Stop stops the ticker. No more events will be sent through the channel
+//
+// A tick is delivered if and only if its scheduled time is before or equal to the internal time at the end of a
+// Forward, Set or similar call that returned before Stop. This includes a tick scheduled exactly at the new internal
+// time. Stop can't retract a tick that has already been delivered: it stays in the channel and can still be received
+// after Stop has returned.
 func (f *fakeTicker) Stop() {
 	f.mu.Lock()
 	f.stopped = true
@@ -48,24 +216,72 @@ func (f *fakeTicker) Stop() {
 
 // Execute executes the Ticker
 func (f *fakeTicker) Execute(t time.Time) {
-	f.mu.RLock()
+	policy := f.clock.TickerPolicy()
+	// Hold the lock until the tick has been sent, so no tick is sent after Stop has returned
+	f.mu.Lock()
+	if f.stopped || f.next.After(t) {
+		f.mu.Unlock()
+		return
+	}
+	if f.ctx != nil && f.ctx.Err() != nil {
+		// Don't rely on the goroutine watching ctx, it might not have stopped the Ticker yet
+		f.stopped = true
+		f.mu.Unlock()
+		f.clock.removeTimer(f)
+		return
+	}
+	atomic.AddInt64(&f.clock.tickerFires, 1)
+	if f.policy != nil {
+		policy = *f.policy
+	}
+	coalesce := policy == Coalesce
 	next := f.next
-	stopped := f.stopped
-	f.mu.RUnlock()
-
-	if stopped {
+	if coalesce && f.interval == nil {
+		// Skip to the latest tick that is due
+		next = next.Add(t.Sub(next) / f.d * f.d)
+	}
+	following := f.after(next)
+	for coalesce && !following.After(t) {
+		// Without a fixed period, the ticks that are due have to be skipped one by one
+		next, following = following, f.after(following)
+	}
+	f.next = following
+	if f.window > 0 && f.delivered != nil && next.Sub(*f.delivered) < f.window {
+		f.mu.Unlock()
 		return
 	}
-
-	f.mu.Lock()
-	f.next = next.Add(f.d)
+	f.clock.logEvent(TickerTicked, next, f)
+	fn := f.fn
+	if fn == nil {
+		select {
+		case f.ch <- next:
+			if f.window > 0 {
+				f.delivered = &next
+			}
+		default:
+		}
+	}
 	f.mu.Unlock()
+	if fn != nil {
+		// The callback is called without holding the lock, so it can cancel its own Ticker
+		fn()
+	}
+	f.clock.sched()
+}
 
-	select {
-	case f.ch <- next:
-	default:
+// after returns the time of the tick that follows the tick at next. The caller must hold f.mu.
+func (f *fakeTicker) after(next time.Time) time.Time {
+	if f.interval != nil {
+		return next.Add(f.interval())
 	}
-	sched()
+	return next.Add(f.d)
+}
+
+// Label returns the label of the Ticker.
+func (f *fakeTicker) Label() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.label
 }
 
 // NextExecution returns the next execution time
@@ -74,3 +290,10 @@ func (f *fakeTicker) NextExecution() time.Time {
 	defer f.mu.RUnlock()
 	return f.next
 }
+
+// shift moves the next execution of the Ticker by d
+func (f *fakeTicker) shift(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.next = f.next.Add(d)
+}
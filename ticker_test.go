@@ -1,6 +1,8 @@
 package clock
 
 import (
+	"context"
+	"math/rand"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -67,6 +69,32 @@ func TestFakeTicker_Stop(t *testing.T) {
 
 }
 
+func TestFakeTicker_StopBoundary(t *testing.T) {
+	clock := NewMock()
+	ticker := clock.NewTicker(time.Hour)
+
+	// Stopped right before the boundary: the tick is never delivered
+	clock.Forward(time.Hour - time.Nanosecond)
+	ticker.Stop()
+	clock.Forward(time.Hour)
+	assert.Empty(t, ticker.Chan())
+
+	// Stopped exactly at the boundary: the tick has been delivered by Forward
+	ticker = clock.NewTicker(time.Hour)
+	start := clock.Now()
+	clock.Forward(time.Hour)
+	// Stop is called between Forward and the receive, the tick can't be retracted
+	ticker.Stop()
+	select {
+	case v := <-ticker.Chan():
+		assert.Equal(t, start.Add(time.Hour), v)
+	default:
+		t.Fatal("tick at the stop instant was not delivered")
+	}
+	clock.Forward(time.Hour)
+	assert.Empty(t, ticker.Chan())
+}
+
 // Make sure the ticker won't block when not read
 func TestFakeTicker_Unread(t *testing.T) {
 	clock := NewMock()
@@ -95,3 +123,347 @@ func TestFakeTicker_Multiple(t *testing.T) {
 		assert.Equal(t, int32(20/(i+1)), atomic.LoadInt32(&executions[i]))
 	}
 }
+
+func TestFakeTicker_NonPositive(t *testing.T) {
+	clock := NewMock()
+	assert.Panics(t, func() { clock.NewTicker(0) })
+	assert.Panics(t, func() { clock.NewTicker(-time.Second) })
+	assert.Zero(t, clock.Len())
+}
+
+func TestFakeTicker_TryNewTicker(t *testing.T) {
+	clock := NewMock()
+	for _, d := range []time.Duration{0, -time.Second} {
+		ticker, err := clock.TryNewTicker(d)
+		assert.Nil(t, ticker)
+		assert.Equal(t, ErrNonPositiveInterval, err)
+	}
+	assert.Zero(t, clock.Len())
+
+	ticker, err := clock.TryNewTicker(time.Second)
+	assert.NoError(t, err)
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(1, 0), <-ticker.Chan())
+}
+
+func TestFakeTicker_NewTickerChan(t *testing.T) {
+	clock := NewMock()
+	ticker, ch := clock.NewTickerChan(time.Second)
+	assert.Equal(t, ticker.Chan(), ch)
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(1, 0), <-ch)
+	ticker.Stop()
+}
+
+func TestFakeTicker_Buffered(t *testing.T) {
+	clock := NewMock()
+	start := clock.Now()
+	ticker := clock.NewBufferedTicker(time.Hour, 3)
+	clock.Forward(5 * time.Hour)
+
+	assert.Len(t, ticker.Chan(), 3)
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Hour), <-ticker.Chan())
+	}
+	assert.Empty(t, ticker.Chan())
+
+	clock.Forward(time.Hour)
+	assert.Equal(t, start.Add(6*time.Hour), <-ticker.Chan())
+	ticker.Stop()
+}
+
+func TestFakeTicker_NewTickerAt(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(100, 0))
+
+	ticker := clock.NewTickerAt(time.Unix(130, 0), time.Minute)
+	clock.Forward(29 * time.Second)
+	assert.Empty(t, ticker.Chan())
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(130, 0), <-ticker.Chan())
+	clock.Forward(time.Minute)
+	assert.Equal(t, time.Unix(190, 0), <-ticker.Chan())
+	ticker.Stop()
+
+	// A start in the past is due immediately
+	ticker = clock.NewTickerAt(time.Unix(0, 0), time.Minute)
+	clock.Forward(0)
+	assert.Equal(t, time.Unix(190, 0), <-ticker.Chan())
+	clock.Forward(time.Minute)
+	assert.Equal(t, time.Unix(250, 0), <-ticker.Chan())
+	ticker.Stop()
+	clock.Forward(time.Hour)
+	assert.Empty(t, ticker.Chan())
+
+	assert.Panics(t, func() { clock.NewTickerAt(time.Unix(0, 0), 0) })
+}
+
+func TestFakeTicker_Aligned(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(1234, 5678))
+	start := clock.Now()
+	ticker := clock.NewBufferedTicker(time.Hour, 10)
+
+	values := make(chan time.Time, 10)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case v := <-ticker.Chan():
+				values <- v
+			case <-done:
+				return
+			}
+		}
+	}()
+	clock.Forward(3 * time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	close(done)
+
+	assert.Len(t, values, 3)
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Hour), <-values)
+	}
+
+	// Dropped ticks don't shift the phase
+	ticker.Stop()
+	unread := clock.NewTicker(time.Hour)
+	clock.Forward(150 * time.Minute)
+	assert.Equal(t, start.Add(4*time.Hour), <-unread.Chan())
+	clock.Forward(30 * time.Minute)
+	assert.Equal(t, start.Add(6*time.Hour), <-unread.Chan())
+	unread.Stop()
+}
+
+func TestFakeTicker_Policy(t *testing.T) {
+	tests := []struct {
+		policy TickerPolicy
+		ticks  []time.Duration
+	}{
+		{CatchUp, []time.Duration{1, 2, 3, 4, 5}},
+		{Coalesce, []time.Duration{5}},
+	}
+	for _, test := range tests {
+		clock := NewMock()
+		clock.SetTickerPolicy(test.policy)
+		start := clock.Now()
+		ticker := clock.NewBufferedTicker(time.Hour, 10)
+		clock.Forward(5*time.Hour + 30*time.Minute)
+
+		var ticks []time.Duration
+		for len(ticker.Chan()) > 0 {
+			ticks = append(ticks, (<-ticker.Chan()).Sub(start)/time.Hour)
+		}
+		assert.Equal(t, test.ticks, ticks)
+
+		// The next tick keeps the phase
+		clock.Forward(30 * time.Minute)
+		assert.Equal(t, start.Add(6*time.Hour), <-ticker.Chan())
+		ticker.Stop()
+	}
+}
+
+func TestFakeTicker_PolicyFor(t *testing.T) {
+	clock := NewMock()
+	coalesced := clock.NewBufferedTicker(time.Hour, 10)
+	caughtUp := clock.NewBufferedTicker(time.Hour, 10)
+	clock.SetTickerPolicyFor(coalesced, Coalesce)
+	clock.Forward(5 * time.Hour)
+	assert.Len(t, coalesced.Chan(), 1)
+	assert.Len(t, caughtUp.Chan(), 5)
+
+	// The per-ticker policy wins over the global one
+	clock.SetTickerPolicy(Coalesce)
+	clock.SetTickerPolicyFor(caughtUp, CatchUp)
+	clock.Forward(3 * time.Hour)
+	assert.Len(t, coalesced.Chan(), 2)
+	assert.Len(t, caughtUp.Chan(), 8)
+}
+
+func TestTickerC(t *testing.T) {
+	for _, c := range []Clock{New(), NewMock()} {
+		ticker := c.NewTicker(time.Hour)
+		assert.Equal(t, ticker.Chan(), TickerC(ticker))
+		ticker.Stop()
+	}
+}
+
+func TestFakeTicker_Chaos(t *testing.T) {
+	run := func(seed int64) []time.Duration {
+		clock := NewMock()
+		start := clock.Now()
+		ticker := clock.NewChaosTicker(time.Second, 10*time.Second, rand.New(rand.NewSource(seed)))
+		defer ticker.Stop()
+		var ticks []time.Duration
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, clock.ForwardTo(ticker.(*fakeTicker).NextExecution()))
+			ticks = append(ticks, (<-ticker.Chan()).Sub(start))
+		}
+		return ticks
+	}
+
+	ticks := run(42)
+	assert.Equal(t, ticks, run(42))
+	assert.NotEqual(t, ticks, run(7))
+	intervals := map[time.Duration]bool{}
+	prev := time.Duration(0)
+	for _, tick := range ticks {
+		d := tick - prev
+		assert.True(t, d >= time.Second && d <= 10*time.Second, "interval %v out of range", d)
+		intervals[d] = true
+		prev = tick
+	}
+	assert.True(t, len(intervals) > 1)
+}
+
+func TestFakeTicker_ChaosCoalesce(t *testing.T) {
+	clock := NewMock()
+	clock.SetTickerPolicy(Coalesce)
+	ticker := clock.NewChaosTicker(time.Second, 2*time.Second, rand.New(rand.NewSource(1)))
+	clock.Forward(time.Minute)
+	tick := <-ticker.Chan()
+	next := ticker.(*fakeTicker).NextExecution()
+	assert.True(t, tick.After(clock.Now().Add(-2*time.Second)))
+	assert.True(t, next.After(clock.Now()))
+}
+
+func TestFakeTicker_ChaosInvalid(t *testing.T) {
+	clock := NewMock()
+	rng := rand.New(rand.NewSource(1))
+	assert.PanicsWithValue(t, "clock: non-positive interval for NewChaosTicker", func() {
+		clock.NewChaosTicker(0, time.Second, rng)
+	})
+	assert.PanicsWithValue(t, "clock: max interval smaller than min interval for NewChaosTicker", func() {
+		clock.NewChaosTicker(time.Second, time.Millisecond, rng)
+	})
+}
+
+func TestFakeTicker_Context(t *testing.T) {
+	clock := NewMock()
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := clock.NewTickerContext(ctx, time.Second)
+	for i := 1; i <= 3; i++ {
+		clock.Forward(time.Second)
+		assert.Equal(t, time.Unix(int64(i), 0), <-ticker.Chan())
+	}
+
+	cancel()
+	clock.Forward(time.Minute)
+	assert.Empty(t, ticker.Chan())
+	assert.Empty(t, clock.PendingTimers())
+	ticker.Stop()
+}
+
+func TestFakeTicker_ContextDone(t *testing.T) {
+	clock := NewMock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ticker := clock.NewTickerContext(ctx, time.Second)
+	assert.Zero(t, clock.Len())
+	clock.Forward(time.Minute)
+	assert.Empty(t, ticker.Chan())
+}
+
+func TestFakeTicker_Coalescing(t *testing.T) {
+	clock := NewMock()
+	ticker := clock.NewCoalescingTicker(time.Second, 5*time.Second)
+	var ticks []time.Time
+	for i := 0; i < 60; i++ {
+		clock.Forward(time.Second)
+		if v, ok := clock.DrainTicker(ticker); ok {
+			ticks = append(ticks, v)
+		}
+	}
+	assert.Len(t, ticks, 12)
+	for i, tick := range ticks {
+		assert.Equal(t, time.Unix(int64(1+5*i), 0), tick)
+	}
+
+	// A window shorter than the period doesn't suppress anything
+	ticker = clock.NewCoalescingTicker(time.Second, time.Millisecond)
+	n := 0
+	for i := 0; i < 10; i++ {
+		clock.Forward(time.Second)
+		if _, ok := clock.DrainTicker(ticker); ok {
+			n++
+		}
+	}
+	assert.Equal(t, 10, n)
+}
+
+func TestFakeTicker_Immediate(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(100, 0))
+	ticker := clock.NewTickerImmediate(time.Minute)
+	assert.Empty(t, ticker.Chan())
+	clock.Forward(0)
+	assert.Equal(t, time.Unix(100, 0), <-ticker.Chan())
+	clock.Forward(59 * time.Second)
+	assert.Empty(t, ticker.Chan())
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(160, 0), <-ticker.Chan())
+	clock.Forward(time.Minute)
+	assert.Equal(t, time.Unix(220, 0), <-ticker.Chan())
+}
+
+func TestFakeTicker_Order(t *testing.T) {
+	tests := []struct {
+		policy TickerOrderPolicy
+		order  []string
+	}{
+		{InsertionOrder, []string{"3s", "1s", "timer", "2s", "1s'"}},
+		{PeriodAscending, []string{"timer", "1s", "1s'", "2s", "3s"}},
+	}
+	for _, test := range tests {
+		clock := NewMock(WithTickerOrder(test.policy))
+		// All tickers coincide at the first second
+		start := clock.Now().Add(time.Second)
+		ticker := func(label string, d time.Duration) {
+			f := clock.NewTickerAt(start, d).(*fakeTicker)
+			f.label = label
+		}
+		ticker("3s", 3*time.Second)
+		ticker("1s", time.Second)
+		clock.NewLabeledTimer(time.Second, "timer")
+		ticker("2s", 2*time.Second)
+		ticker("1s'", time.Second)
+
+		var order []string
+		clock.BeforeFire(func(e Executer, _ time.Time) {
+			order = append(order, e.(Labeled).Label())
+		})
+		clock.Forward(time.Second)
+		assert.Equal(t, test.order, order)
+	}
+}
+
+func TestFakeTicker_Every(t *testing.T) {
+	clock := NewMock()
+	count := 0
+	cancel := clock.Every(time.Second, func() { count++ })
+	assert.Equal(t, []PendingEvent{{At: time.Unix(1, 0), Kind: TickerEvent, Mode: FuncDelivery}}, clock.PendingTimers())
+	clock.Forward(500 * time.Millisecond)
+	assert.Equal(t, 0, count)
+	clock.Forward(500 * time.Millisecond)
+	assert.Equal(t, 1, count)
+	// Every elapsed period calls the function
+	clock.Forward(3 * time.Second)
+	assert.Equal(t, 4, count)
+
+	cancel()
+	clock.Forward(time.Hour)
+	assert.Equal(t, 4, count)
+	assert.Zero(t, clock.Len())
+
+	// The function can cancel itself
+	count = 0
+	cancel = clock.Every(time.Second, func() {
+		count++
+		if count == 2 {
+			cancel()
+		}
+	})
+	clock.Forward(time.Hour)
+	assert.Equal(t, 2, count)
+	assert.Panics(t, func() { clock.Every(0, func() {}) })
+}
@@ -2,6 +2,7 @@ package clock
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -63,6 +64,54 @@ type Timer interface {
 	Reset(time.Duration) bool
 }
 
+// FiredTimer is a Timer that reports whether it has fired. The Timers created by a Mock implement it.
+type FiredTimer interface {
+	Timer
+	// Fired returns true if the Timer has fired since it was created or last reset.
+	Fired() bool
+}
+
+// RemainingTimer is a Timer that reports how long it takes until it fires. Only the Timers created by a Mock
+// implement it, the time package doesn't expose the due time of a time.Timer.
+type RemainingTimer interface {
+	Timer
+	// Remaining returns the duration until the Timer fires. It returns 0 if the Timer is already due.
+	Remaining() time.Duration
+}
+
+// TimerState describes whether a Timer is still pending, has fired or has been stopped.
+type TimerState int
+
+const (
+	// Pending means the Timer has neither fired nor been stopped
+	Pending TimerState = iota
+	// Fired means the Timer has fired. It stays Fired if it is stopped afterwards
+	Fired
+	// Stopped means the Timer has been stopped before it fired
+	Stopped
+)
+
+// StatefulTimer is a Timer that reports its TimerState. The Timers created by a Mock and the Timers returned by
+// AfterFunc of a real Clock implement it.
+type StatefulTimer interface {
+	Timer
+	// State returns whether the Timer is pending, has fired or has been stopped since it was created or last reset.
+	State() TimerState
+}
+
+// Labeled is implemented by the Timers and Tickers of a Mock. It can be used to identify them, e.g. in the hook
+// registered with Mock.BeforeFire.
+type Labeled interface {
+	// Label returns the label set with NewLabeledTimer or NewLabeledTicker, or an empty string.
+	Label() string
+}
+
+// TimerC returns the channel of t. It eases migrating code that reads the field time.Timer.C: replace t.C with
+// clock.TimerC(t).
+func TimerC(t Timer) <-chan time.Time {
+	return t.Chan()
+}
+
 // realTimer is just the type time.Timer and implements the Timer interface.
 type realTimer struct {
 	*time.Timer
@@ -73,14 +122,60 @@ func (r *realTimer) Chan() <-chan time.Time {
 	return r.C
 }
 
+// funcTimer is the Timer returned by AfterFunc of a real Clock. It tracks its TimerState, which the type time.Timer
+// doesn't expose.
+type funcTimer struct {
+	realTimer
+	state int32
+}
+
+// newFuncTimer creates a funcTimer that runs fn after d.
+func newFuncTimer(d time.Duration, fn func()) *funcTimer {
+	t := &funcTimer{}
+	t.Timer = time.AfterFunc(d, func() {
+		atomic.StoreInt32(&t.state, int32(Fired))
+		fn()
+	})
+	return t
+}
+
+// Stop prevents the Timer from firing. It returns true if the call stops the timer, false if the timer has already
+// expired or been stopped.
+func (t *funcTimer) Stop() bool {
+	if !t.Timer.Stop() {
+		return false
+	}
+	atomic.StoreInt32(&t.state, int32(Stopped))
+	return true
+}
+
+// Reset changes the timer to expire after duration d. It returns true if the timer had been active, false if the
+// timer had expired or been stopped.
+func (t *funcTimer) Reset(d time.Duration) bool {
+	atomic.StoreInt32(&t.state, int32(Pending))
+	return t.Timer.Reset(d)
+}
+
+// State returns the TimerState of the Timer.
+func (t *funcTimer) State() TimerState {
+	return TimerState(atomic.LoadInt32(&t.state))
+}
+
 // fakeTimer is an implementation of Timer that's based on the time mocking done in Mock.
 type fakeTimer struct {
+	tracking
 	mu      sync.RWMutex
 	ch      chan time.Time
 	fn      func()
 	due     time.Time
 	clock   *Mock
 	stopped bool
+	// waiter is true if the Timer was created by After and only serves to wake up a waiting goroutine
+	waiter bool
+	// fired is true if the Timer has executed since it was created or last reset
+	fired bool
+	// label identifies the Timer in debugging output
+	label string
 }
 
 // Chan returns the readonly channel of the Timer.
@@ -112,14 +207,19 @@ func (f *fakeTimer) Chan() <-chan time.Time {
 // If the caller needs to know whether f is completed, it must coordinate
 // with f explicitly.
 func (f *fakeTimer) Stop() bool {
-	f.clock.removeTimer(f)
+	f.clock.record(f, opRecord{op: OpStop})
+	// The lock of the Mock is always acquired before the lock of the Timer
+	f.clock.mu.Lock()
+	defer f.clock.mu.Unlock()
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.clock.removeTimerLocked(f)
 
 	if f.stopped {
 		return false
 	}
 	f.stopped = true
+	f.clock.logEvent(TimerStopped, f.clock.now, f)
 	return true
 }
 
@@ -148,40 +248,142 @@ func (f *fakeTimer) Stop() bool {
 // is a race condition between draining the channel and the new timer expiring.
 // Reset should always be invoked on stopped or expired channels, as described above.
 // The return value exists to preserve compatibility with existing programs.
+//
+// Unlike the time package, the fake Timer drains a value that has not been received from its channel yet before
+// rearming. This way, the channel never holds a stale value.
+// The fake Timer is active until it fires or is stopped, so Reset returns false for a Timer that has fired even if
+// its value hasn't been received yet. Since Go 1.23, the time package reports such a Timer as active.
+//
+// If d <= 0, the Timer fires without the internal time having to be forwarded, like a Timer created by NewTimer or
+// AfterFunc with a non-positive duration.
 func (f *fakeTimer) Reset(d time.Duration) bool {
+	active := f.reset(d)
+	if d <= 0 {
+		f.clock.fireDue(f)
+	}
+	return active
+}
+
+// reset rearms the Timer to expire after d, see Reset.
+func (f *fakeTimer) reset(d time.Duration) bool {
 	now := f.clock.Now()
+	f.clock.mu.Lock()
+	defer f.clock.mu.Unlock()
 	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.due = now.Add(d)
+	f.fired = false
+	f.clock.record(f, opRecord{op: OpReset, undrained: f.stopped && len(f.ch) > 0})
+	f.clock.logEvent(TimerReset, now, f)
+	if f.ch != nil {
+		select {
+		case <-f.ch:
+		default:
+		}
+	}
 
 	if f.stopped {
+		// The channel of a stopped Timer might have been closed by Close
+		if f.clock.closed {
+			return false
+		}
 		f.stopped = false
-		f.clock.addTimer(f)
-		f.mu.Unlock()
+		f.clock.addTimerLocked(f)
 		return false
 	}
-	f.mu.Unlock()
-
 	return true
 }
 
 // Execute executes the Timer object
 func (f *fakeTimer) Execute(t time.Time) {
-	f.mu.RLock()
-	if f.stopped {
-		f.mu.RUnlock()
-		return
-	}
-	f.mu.RUnlock()
-
+	m := f.clock
+	m.mu.Lock()
 	f.mu.Lock()
-	if f.ch == nil {
-		f.fn()
-	} else {
-		f.ch <- f.due
+	// The Timer might have been stopped or reset since it was picked for execution
+	if f.stopped || f.due.After(t) {
+		f.mu.Unlock()
+		m.mu.Unlock()
+		return
 	}
+	m.record(f, opRecord{op: OpExecute})
+	m.logEvent(TimerFired, f.due, f)
+	atomic.AddInt64(&m.timerFires, 1)
+	// Mark the Timer as expired before running the callback. This way, the callback can Stop or Reset its own Timer
 	f.stopped = true
+	f.fired = true
+	fn, ch := f.fn, f.ch
+	dropped := false
+	if ch != nil {
+		// The send must never block while f.mu is held, otherwise a full buffer would wedge Stop, Reset and the tick
+		// loop of the Mock. If the value doesn't fit, it is dropped and reported to the droppedSend handler.
+		select {
+		case ch <- f.due:
+		default:
+			dropped = true
+		}
+	}
+	due := f.due
+	m.removeTimerLocked(f)
 	f.mu.Unlock()
-	f.clock.removeTimer(f)
+	m.mu.Unlock()
+
+	if dropped && m.sendTimeout > 0 {
+		// Give a slow reader the chance to make room, without holding any lock
+		select {
+		case ch <- due:
+			dropped = false
+		case <-time.After(m.sendTimeout):
+		}
+	}
+	if dropped {
+		atomic.AddInt64(&m.droppedSends, 1)
+		if m.droppedSend != nil {
+			m.droppedSend(f)
+		}
+	}
+	if ch == nil {
+		if fn == nil {
+			panic("nil callback")
+		}
+		fn()
+	}
+}
+
+// Fired returns true if the Timer has fired since it was created or last reset. Unlike a read from the channel, it
+// doesn't consume the value that has been sent.
+func (f *fakeTimer) Fired() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.fired
+}
+
+// State returns the TimerState of the Timer.
+func (f *fakeTimer) State() TimerState {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	switch {
+	case f.fired:
+		return Fired
+	case f.stopped:
+		return Stopped
+	}
+	return Pending
+}
+
+// Label returns the label of the Timer.
+func (f *fakeTimer) Label() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.label
+}
+
+// Remaining returns the duration until the Timer fires in comparison to the internal time. It returns 0 if the Timer
+// is already due. For a stopped Timer, it returns the duration until it would have fired.
+func (f *fakeTimer) Remaining() time.Duration {
+	if d := f.NextExecution().Sub(f.clock.Now()); d > 0 {
+		return d
+	}
+	return 0
 }
 
 // NextExecution returns the next execution time
@@ -190,3 +392,10 @@ func (f *fakeTimer) NextExecution() time.Time {
 	defer f.mu.RUnlock()
 	return f.due
 }
+
+// shift moves the due time of the Timer by d
+func (f *fakeTimer) shift(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.due = f.due.Add(d)
+}
@@ -88,3 +88,384 @@ func TestFakeTimer_Reset(t *testing.T) {
 		assert.Equal(t, test.count2, atomic.LoadInt32(&executed))
 	}
 }
+
+func TestFakeTimer_NonPositive(t *testing.T) {
+	for _, d := range []time.Duration{0, -1, -time.Hour} {
+		clock := NewMock()
+		now := clock.Now()
+		timer := clock.NewTimer(d)
+		select {
+		case v := <-timer.Chan():
+			assert.Equal(t, now, v)
+		default:
+			t.Errorf("timer with duration %v did not fire immediately", d)
+		}
+		assert.False(t, timer.Stop())
+		assert.Zero(t, clock.Len())
+	}
+}
+
+func TestFakeTimer_DroppedSend(t *testing.T) {
+	var dropped []Timer
+	clock := NewMock(WithDroppedSendHandler(func(t Timer) { dropped = append(dropped, t) }))
+	timer := clock.NewTimer(time.Second)
+
+	// Fill the buffer of the channel so the value doesn't fit anymore
+	stale := time.Unix(-1, 0)
+	timer.(*fakeTimer).ch <- stale
+	clock.Forward(time.Second)
+	assert.Equal(t, []Timer{timer}, dropped)
+	assert.Equal(t, stale, <-timer.Chan())
+}
+
+func TestFakeTimer_DoubleFire(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Second)
+	clock.Forward(time.Second)
+
+	// Rearm the timer without draining its channel, so the second fire finds the buffer full
+	f := timer.(*fakeTimer)
+	f.mu.Lock()
+	f.stopped = false
+	f.mu.Unlock()
+	clock.addTimer(f)
+
+	done := make(chan struct{})
+	go func() {
+		clock.Forward(time.Second)
+		timer.Stop()
+		f.NextExecution()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deadlock on a full timer channel")
+	}
+	assert.Equal(t, time.Unix(1, 0), <-timer.Chan())
+}
+
+func TestFakeTimer_ResetDrains(t *testing.T) {
+	dropped := 0
+	clock := NewMock(WithDroppedSendHandler(func(Timer) { dropped++ }))
+	timer := clock.NewTimer(time.Second)
+
+	// Fire the timer without reading its channel
+	clock.Forward(time.Second)
+	assert.False(t, timer.Reset(time.Second))
+	clock.Forward(time.Second)
+
+	assert.Len(t, timer.Chan(), 1)
+	assert.Equal(t, time.Unix(2, 0), <-timer.Chan())
+	assert.Zero(t, dropped)
+}
+
+func TestFakeTimer_StopInCallback(t *testing.T) {
+	clock := NewMock()
+	var timer Timer
+	stopped := make(chan bool, 1)
+	timer = clock.AfterFunc(time.Second, func() {
+		stopped <- timer.Stop()
+	})
+
+	clock.Forward(time.Second)
+	select {
+	case v := <-stopped:
+		assert.False(t, v)
+	case <-time.After(time.Second):
+		t.Fatal("callback did not return")
+	}
+	assert.Zero(t, clock.Len())
+}
+
+func TestFakeTimer_ResetInCallback(t *testing.T) {
+	clock := NewMock()
+	var timer Timer
+	count := 0
+	timer = clock.AfterFunc(time.Second, func() {
+		count++
+		if count < 3 {
+			timer.Reset(time.Second)
+		}
+	})
+
+	for i := 0; i < 5; i++ {
+		clock.Forward(time.Second)
+	}
+	assert.Equal(t, 3, count)
+	assert.Zero(t, clock.Len())
+}
+
+func TestFakeTimer_ResetNonPositive(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Hour)
+	fired := make(chan struct{})
+	fn := clock.AfterFunc(time.Hour, func() { close(fired) })
+
+	// Both fire without forwarding the internal time, like Timers created with a non-positive duration
+	assert.True(t, timer.Reset(0))
+	assert.Equal(t, time.Unix(0, 0), <-timer.Chan())
+	assert.True(t, fn.Reset(0))
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("callback did not run")
+	}
+	assert.Zero(t, clock.Len())
+
+	// Reset from a callback fires during the same Forward
+	count := 0
+	fn = clock.AfterFunc(time.Second, func() {
+		count++
+		if count < 3 {
+			fn.Reset(0)
+		}
+	})
+	clock.Forward(time.Second)
+	assert.Equal(t, 3, count)
+}
+
+func TestFakeTimer_NewTimerAt(t *testing.T) {
+	clock := NewMock()
+	clock.Set(time.Unix(100, 0))
+
+	timer := clock.NewTimerAt(time.Unix(160, 0))
+	clock.Forward(59 * time.Second)
+	assert.Empty(t, timer.Chan())
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(160, 0), <-timer.Chan())
+
+	// A time in the past fires immediately
+	timer = clock.NewTimerAt(time.Unix(100, 0))
+	assert.Equal(t, time.Unix(160, 0), <-timer.Chan())
+	assert.Zero(t, clock.Len())
+}
+
+func TestTimerC(t *testing.T) {
+	for _, c := range []Clock{New(), NewMock()} {
+		timer := c.NewTimer(time.Hour)
+		assert.Equal(t, timer.Chan(), TimerC(timer))
+		timer.Stop()
+	}
+}
+
+func TestFakeTimer_Fired(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Minute).(FiredTimer)
+	assert.False(t, timer.Fired())
+	clock.Forward(time.Minute)
+	assert.True(t, timer.Fired())
+	// The value has not been consumed
+	assert.Len(t, timer.Chan(), 1)
+	// Stopping an expired timer doesn't change whether it has fired
+	assert.False(t, timer.Stop())
+	assert.True(t, timer.Fired())
+
+	timer.Reset(time.Minute)
+	assert.False(t, timer.Fired())
+	assert.True(t, timer.Stop())
+	clock.Forward(time.Hour)
+	assert.False(t, timer.Fired())
+
+	timer = clock.AfterFunc(time.Second, func() {}).(FiredTimer)
+	clock.Forward(time.Second)
+	assert.True(t, timer.Fired())
+}
+
+func TestFakeTimer_Remaining(t *testing.T) {
+	clock := NewMock()
+	timer := clock.NewTimer(time.Minute).(RemainingTimer)
+	assert.Equal(t, time.Minute, timer.Remaining())
+	clock.Forward(20 * time.Second)
+	assert.Equal(t, 40*time.Second, timer.Remaining())
+	clock.Forward(time.Hour)
+	assert.Zero(t, timer.Remaining())
+
+	_, ok := New().NewTimer(time.Minute).(RemainingTimer)
+	assert.False(t, ok)
+}
+
+func TestFakeTimer_NewTimerReset(t *testing.T) {
+	clock := NewMock()
+	timer, reset := clock.NewTimerReset(time.Second)
+	// Debounce: every event pushes the deadline back
+	for i := 0; i < 10; i++ {
+		clock.Forward(500 * time.Millisecond)
+		reset(time.Second)
+	}
+	assert.Empty(t, timer.Chan())
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(6, 0), <-timer.Chan())
+
+	// A value that hasn't been received is drained
+	reset(time.Second)
+	clock.Forward(time.Second)
+	reset(time.Second)
+	assert.Empty(t, timer.Chan())
+	clock.Forward(time.Second)
+	assert.Equal(t, time.Unix(8, 0), <-timer.Chan())
+	assert.Empty(t, timer.Chan())
+}
+
+func TestFakeTimer_State(t *testing.T) {
+	clock := NewMock()
+	// Fire first
+	timer := clock.AfterFunc(time.Second, func() {}).(StatefulTimer)
+	assert.Equal(t, Pending, timer.State())
+	clock.Forward(time.Second)
+	assert.Equal(t, Fired, timer.State())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, Fired, timer.State())
+
+	// Stop first
+	timer.Reset(time.Second)
+	assert.Equal(t, Pending, timer.State())
+	assert.True(t, timer.Stop())
+	assert.Equal(t, Stopped, timer.State())
+	clock.Forward(time.Second)
+	assert.Equal(t, Stopped, timer.State())
+
+	timer = clock.NewTimer(time.Second).(StatefulTimer)
+	clock.Forward(time.Second)
+	assert.Equal(t, Fired, timer.State())
+}
+
+func TestFuncTimer_State(t *testing.T) {
+	// Fire first
+	done := make(chan struct{})
+	timer := New().AfterFunc(time.Millisecond, func() { close(done) }).(StatefulTimer)
+	<-done
+	assert.Equal(t, Fired, timer.State())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, Fired, timer.State())
+
+	// Stop first
+	timer = New().AfterFunc(time.Hour, func() {}).(StatefulTimer)
+	assert.Equal(t, Pending, timer.State())
+	assert.True(t, timer.Stop())
+	assert.Equal(t, Stopped, timer.State())
+	timer.Reset(time.Hour)
+	assert.Equal(t, Pending, timer.State())
+	timer.Stop()
+}
+
+func TestFakeTimer_ResetReturn(t *testing.T) {
+	tests := []struct {
+		name   string
+		create func(c Clock) Timer
+		// prepare brings the timer into the state under test, fire makes the timer fire
+		prepare func(timer Timer, fire func())
+		active  bool
+	}{
+		{
+			name:    "pending",
+			create:  func(c Clock) Timer { return c.NewTimer(time.Hour) },
+			prepare: func(Timer, func()) {},
+			active:  true,
+		},
+		{
+			name:    "fired",
+			create:  func(c Clock) Timer { return c.NewTimer(time.Millisecond) },
+			prepare: func(_ Timer, fire func()) { fire() },
+		},
+		{
+			name:    "fired immediately",
+			create:  func(c Clock) Timer { return c.NewTimer(0) },
+			prepare: func(_ Timer, fire func()) { fire() },
+		},
+		{
+			name:    "stopped",
+			create:  func(c Clock) Timer { return c.NewTimer(time.Hour) },
+			prepare: func(timer Timer, _ func()) { timer.Stop() },
+		},
+		{
+			name:    "fired func",
+			create:  func(c Clock) Timer { return c.AfterFunc(time.Millisecond, func() {}) },
+			prepare: func(_ Timer, fire func()) { fire() },
+		},
+		{
+			name:    "stopped func",
+			create:  func(c Clock) Timer { return c.AfterFunc(time.Hour, func() {}) },
+			prepare: func(timer Timer, _ func()) { timer.Stop() },
+		},
+	}
+	// receive waits until the value of a timer has been received. Since Go 1.23, a real timer whose value hasn't
+	// been received yet is still considered active.
+	receive := func(timer Timer) {
+		if timer.Chan() != nil {
+			<-timer.Chan()
+		}
+	}
+	for _, test := range tests {
+		clock := NewMock()
+		timer := test.create(clock)
+		test.prepare(timer, func() {
+			clock.Forward(time.Millisecond)
+			receive(timer)
+		})
+		assert.Equal(t, test.active, timer.Reset(time.Hour), "mock: %s", test.name)
+		// A second Reset always finds the timer active
+		assert.True(t, timer.Reset(time.Hour), "mock: %s", test.name)
+
+		// The real Clock agrees
+		timer = test.create(New())
+		test.prepare(timer, func() {
+			time.Sleep(20 * time.Millisecond)
+			receive(timer)
+		})
+		assert.Equal(t, test.active, timer.Reset(time.Hour), "real: %s", test.name)
+		timer.Stop()
+	}
+}
+
+func TestFakeTimer_SendTimeout(t *testing.T) {
+	stale := time.Unix(-1, 0)
+
+	// The slow reader makes room within the timeout
+	clock := NewMock(WithSendTimeout(time.Second))
+	timer := clock.NewTimer(time.Second)
+	timer.(*fakeTimer).ch <- stale
+	received := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		received <- <-timer.Chan()
+	}()
+	clock.Forward(time.Second)
+	assert.Equal(t, stale, <-received)
+	assert.Equal(t, time.Unix(1, 0), <-timer.Chan())
+	assert.Zero(t, clock.Stats().DroppedSends)
+
+	// Nobody reads, the value is dropped after the timeout
+	dropped := 0
+	clock = NewMock(WithSendTimeout(10*time.Millisecond), WithDroppedSendHandler(func(Timer) { dropped++ }))
+	timer = clock.NewTimer(time.Second)
+	timer.(*fakeTimer).ch <- stale
+	start := time.Now()
+	clock.Forward(time.Second)
+	assert.True(t, time.Since(start) >= 10*time.Millisecond)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 1, clock.Stats().DroppedSends)
+	assert.Equal(t, stale, <-timer.Chan())
+}
+
+func TestFakeTimer_NewTimerCapped(t *testing.T) {
+	clock := NewMock()
+	// d exceeds the cap, the timer fires at the cap
+	timer := clock.NewTimerCapped(time.Hour, time.Unix(60, 0))
+	clock.Forward(59 * time.Second)
+	assert.Empty(t, timer.Chan())
+	clock.Forward(time.Hour)
+	assert.Equal(t, time.Unix(60, 0), <-timer.Chan())
+
+	// d doesn't exceed the cap, the timer fires after d
+	timer = clock.NewTimerCapped(time.Minute, clock.Now().Add(time.Hour))
+	clock.Forward(time.Minute)
+	assert.Equal(t, clock.Now(), <-timer.Chan())
+
+	// The cap has already passed or is the internal time, the timer fires immediately like a real one
+	for _, notAfter := range []time.Time{time.Unix(0, 0), clock.Now()} {
+		timer = clock.NewTimerCapped(time.Minute, notAfter)
+		assert.Equal(t, clock.Now(), <-timer.Chan())
+		assert.Zero(t, clock.Len())
+	}
+}